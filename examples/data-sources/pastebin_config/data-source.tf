@@ -0,0 +1,9 @@
+data "pastebin_config" "current" {}
+
+output "pastebin_host" {
+  value = data.pastebin_config.current.host
+}
+
+output "pastebin_authenticated" {
+  value = data.pastebin_config.current.user_key_configured
+}
@@ -0,0 +1,7 @@
+data "pastebin_formats" "scripts" {
+  filter = "script"
+}
+
+output "script_formats" {
+  value = data.pastebin_formats.scripts.formats
+}
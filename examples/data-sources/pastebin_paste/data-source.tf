@@ -0,0 +1,8 @@
+data "pastebin_paste" "example" {
+  key = "abcd1234"
+}
+
+# A paste url copied from the browser can be used as key
+data "pastebin_paste" "from_url" {
+  key = "https://pastebin.com/raw/abcd1234"
+}
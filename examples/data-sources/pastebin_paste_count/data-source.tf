@@ -0,0 +1,8 @@
+data "pastebin_paste_count" "private_yaml" {
+  format     = "yaml"
+  visibility = "private"
+}
+
+output "private_yaml_paste_count" {
+  value = data.pastebin_paste_count.private_yaml.total
+}
@@ -0,0 +1,7 @@
+data "pastebin_paste_info" "example" {
+  key = "abcd1234"
+}
+
+output "paste_hits" {
+  value = data.pastebin_paste_info.example.hits
+}
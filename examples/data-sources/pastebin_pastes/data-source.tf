@@ -0,0 +1,7 @@
+data "pastebin_pastes" "example" {
+  limit = 10
+}
+
+output "paste_titles" {
+  value = { for paste in data.pastebin_pastes.example.pastes : paste.key => paste.title }
+}
@@ -0,0 +1,7 @@
+data "pastebin_raw" "example" {
+  key = "abcd1234"
+}
+
+output "greeting" {
+  value = templatefile("greeting.tftpl", { message = data.pastebin_raw.example.content })
+}
@@ -0,0 +1,7 @@
+data "pastebin_search_pastes" "deployments" {
+  query = "deployment"
+}
+
+output "deployment_paste_keys" {
+  value = data.pastebin_search_pastes.deployments.pastes[*].key
+}
@@ -0,0 +1,5 @@
+data "pastebin_trends" "example" {}
+
+output "trending_paste_hits" {
+  value = { for paste in data.pastebin_trends.example.pastes : paste.key => paste.hits }
+}
@@ -0,0 +1,6 @@
+data "pastebin_user" "current" {}
+
+resource "pastebin_paste" "example" {
+  content = "Hello from Terraform."
+  format  = data.pastebin_user.current.default_format
+}
@@ -0,0 +1,8 @@
+variable "format" {
+  type = string
+
+  validation {
+    condition     = provider::pastebin::is_valid_format(var.format)
+    error_message = "The format must be a supported Pastebin syntax highlighting format."
+  }
+}
@@ -0,0 +1,7 @@
+locals {
+  paste = provider::pastebin::parse_paste_url("https://pastebin.com/raw/abcd1234")
+}
+
+output "paste_key" {
+  value = local.paste.key
+}
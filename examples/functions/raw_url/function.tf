@@ -0,0 +1,7 @@
+locals {
+  raw_url = provider::pastebin::raw_url("abcd1234")
+}
+
+output "self_hosted_raw_url" {
+  value = provider::pastebin::raw_url("abcd1234", "https://paste.example.com")
+}
@@ -0,0 +1,9 @@
+resource "pastebin_cleanup" "ci" {
+  title_prefix = "ci-"
+  older_than   = "720h"
+  dry_run      = true
+}
+
+output "cleaned_up_paste_keys" {
+  value = pastebin_cleanup.ci.deleted_keys
+}
@@ -0,0 +1,15 @@
+locals {
+  folder_key = "snippets"
+}
+
+resource "pastebin_paste" "snippet" {
+  content = "echo 'Hello from Terraform.'"
+  format  = "bash"
+  folder  = local.folder_key
+}
+
+resource "pastebin_folder" "snippets" {
+  folder_key = local.folder_key
+  paste_keys = [pastebin_paste.snippet.id]
+  cascade    = true
+}
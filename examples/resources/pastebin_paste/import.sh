@@ -0,0 +1,9 @@
+# A paste can be imported by specifying its paste key.
+terraform import pastebin_paste.example abcd1234
+
+# Multiple pastes are imported with an import block per paste key.
+# import {
+#   for_each = toset(["abcd1234", "efgh5678"])
+#   to       = pastebin_paste.imported[each.key]
+#   id       = each.key
+# }
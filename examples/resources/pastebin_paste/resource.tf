@@ -0,0 +1,6 @@
+resource "pastebin_paste" "example" {
+  title   = "Hello, World!"
+  content = "Hello from Terraform."
+  expire  = "1W"
+  format  = "text"
+}
@@ -0,0 +1,13 @@
+resource "pastebin_paste" "example" {
+  content = "Hello from Terraform."
+}
+
+resource "pastebin_paste_orphan_cleanup" "example" {
+  managed_keys     = [pastebin_paste.example.id]
+  dry_run          = false
+  confirm_deletion = true
+}
+
+output "orphaned_paste_keys" {
+  value = pastebin_paste_orphan_cleanup.example.deleted_keys
+}
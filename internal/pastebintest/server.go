@@ -0,0 +1,313 @@
+// Package pastebintest provides a fake Pastebin server for tests, which
+// implements the subset of the Pastebin API that the provider uses.
+package pastebintest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Paste is a paste stored by the fake server.
+type Paste struct {
+	Key     string
+	Content string
+	Title   string
+	Format  string
+	Expire  string
+	// Private is the api_paste_private value: 0 public, 1 unlisted, 2 private.
+	Private string
+	// Owner is the user key of the user that created the paste, or empty for
+	// a guest paste.
+	Owner string
+	Date  int64
+	// Hits is the number of times the paste was viewed.
+	Hits int64
+}
+
+// Response is a response that is injected into the fake server.
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+// Server is a fake Pastebin server. It accepts the DevKey and UserKey, which
+// default to dev and user, and the ScrapingApiKey of its scraping api, which
+// defaults to scraping. It stores its pastes in memory.
+type Server struct {
+	*httptest.Server
+
+	DevKey      string
+	UserKey     string
+	UserName    string
+	AccountType string
+
+	ScrapingApiKey string
+
+	mu        sync.Mutex
+	pastes    map[string]*Paste
+	next      int
+	injected  map[string][]Response
+	requested map[string]int
+}
+
+// New starts a new fake Pastebin server without pastes. The caller must close
+// the server when done.
+func New() *Server {
+	s := &Server{
+		DevKey:         "dev",
+		UserKey:        "user",
+		UserName:       "terraform",
+		AccountType:    "0",
+		ScrapingApiKey: "scraping",
+		pastes:         map[string]*Paste{},
+		injected:       map[string][]Response{},
+		requested:      map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// AddPaste stores the paste and returns its key, which is generated if the
+// paste has no key.
+func (s *Server) AddPaste(paste Paste) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addPaste(paste)
+}
+
+// addPaste stores the paste and returns its key. The caller must hold mu.
+func (s *Server) addPaste(paste Paste) string {
+	if paste.Key == "" {
+		s.next++
+		paste.Key = fmt.Sprintf("fake%04d", s.next)
+	}
+	if paste.Private == "" {
+		paste.Private = "1"
+	}
+	if paste.Date == 0 {
+		paste.Date = time.Now().Unix()
+	}
+	s.pastes[paste.Key] = &paste
+	return paste.Key
+}
+
+// Paste returns the paste with the given key, if it exists.
+func (s *Server) Paste(key string) (Paste, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	paste, ok := s.pastes[key]
+	if !ok {
+		return Paste{}, false
+	}
+	return *paste, true
+}
+
+// InjectResponse makes the next request for the api option respond with the
+// response instead of being handled. The raw content of a paste that is read
+// without a user key is requested with the raw option, and through the
+// scraping api with the scrape_item option. Injected responses of the same
+// option are returned in order.
+func (s *Server) InjectResponse(option string, response Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.injected[option] = append(s.injected[option], response)
+}
+
+// InjectError makes the next request for the api option fail with the bad
+// api request message, such as "Bad API request, IP blocked".
+func (s *Server) InjectError(option, message string) {
+	s.InjectResponse(option, Response{StatusCode: http.StatusOK, Body: message})
+}
+
+// Requests returns the number of requests for the api option.
+func (s *Server) Requests(option string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requested[option]
+}
+
+// handle handles a request to the fake server.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	option := r.FormValue("api_option")
+	if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/raw/") {
+		option = "raw"
+	}
+	if r.Method == http.MethodGet && r.URL.Path == "/api_scrape_item.php" {
+		option = "scrape_item"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requested[option]++
+	if injected := s.injected[option]; len(injected) > 0 {
+		s.injected[option] = injected[1:]
+		w.WriteHeader(injected[0].StatusCode)
+		_, _ = w.Write([]byte(injected[0].Body))
+		return
+	}
+
+	if option == "raw" {
+		s.handleRaw(w, strings.TrimPrefix(r.URL.Path, "/raw/"))
+		return
+	}
+	if option == "scrape_item" {
+		s.handleScrapeItem(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.FormValue("api_dev_key") != s.DevKey {
+		_, _ = w.Write([]byte("Bad API request, invalid api_dev_key"))
+		return
+	}
+	userKey := r.FormValue("api_user_key")
+	if userKey != "" && userKey != s.UserKey {
+		_, _ = w.Write([]byte("Bad API request, invalid api_user_key"))
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/api/api_post.php" && option == "paste":
+		_, _ = w.Write([]byte(s.handleCreate(r, userKey)))
+	case r.URL.Path == "/api/api_post.php" && option == "trends":
+		_, _ = w.Write([]byte(s.pasteList("", 18)))
+	case userKey == "":
+		_, _ = w.Write([]byte("Bad API request, invalid api_user_key"))
+	case r.URL.Path == "/api/api_post.php" && option == "delete":
+		_, _ = w.Write([]byte(s.handleDelete(r.FormValue("api_paste_key"), userKey)))
+	case r.URL.Path == "/api/api_post.php" && option == "list":
+		limit, err := strconv.Atoi(r.FormValue("api_results_limit"))
+		if err != nil {
+			limit = 50
+		}
+		_, _ = w.Write([]byte(s.pasteList(userKey, limit)))
+	case r.URL.Path == "/api/api_post.php" && option == "userdetails":
+		_, _ = w.Write([]byte(s.userDetails()))
+	case r.URL.Path == "/api/api_raw.php" && option == "show_paste":
+		paste, ok := s.pastes[r.FormValue("api_paste_key")]
+		if !ok || paste.Owner != userKey {
+			_, _ = w.Write([]byte("Bad API request, invalid permission to view this paste or invalid api_paste_key"))
+			return
+		}
+		_, _ = w.Write([]byte(paste.Content))
+	default:
+		_, _ = w.Write([]byte("Bad API request, invalid api_option"))
+	}
+}
+
+// handleRaw writes the content of a public or unlisted paste.
+func (s *Server) handleRaw(w http.ResponseWriter, key string) {
+	paste, ok := s.pastes[key]
+	if !ok || paste.Private == "2" {
+		http.NotFound(w, nil)
+		return
+	}
+	_, _ = w.Write([]byte(paste.Content))
+}
+
+// handleScrapeItem writes the content of a public or unlisted paste, like
+// the scraping api. Pastebin authenticates the scraping api by IP address,
+// which the fake server replaces with the X-Scraping-Api-Key header.
+func (s *Server) handleScrapeItem(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Scraping-Api-Key") != s.ScrapingApiKey {
+		_, _ = w.Write([]byte("YOUR IP: 127.0.0.1 DOES NOT HAVE ACCESS. VISIT: https://pastebin.com/doc_scraping_api TO GET ACCESS!"))
+		return
+	}
+	paste, ok := s.pastes[r.URL.Query().Get("i")]
+	if !ok || paste.Private == "2" {
+		_, _ = w.Write([]byte("Error, we cannot find this paste."))
+		return
+	}
+	_, _ = w.Write([]byte(paste.Content))
+}
+
+// handleCreate creates a paste and returns the response body.
+func (s *Server) handleCreate(r *http.Request, userKey string) string {
+	paste := Paste{
+		Content: r.FormValue("api_paste_code"),
+		Title:   r.FormValue("api_paste_name"),
+		Format:  r.FormValue("api_paste_format"),
+		Expire:  r.FormValue("api_paste_expire_date"),
+		Private: r.FormValue("api_paste_private"),
+		Owner:   userKey,
+	}
+	switch {
+	case paste.Content == "":
+		return "Bad API request, api_paste_code was empty"
+	case paste.Private != "" && paste.Private != "0" && paste.Private != "1" && paste.Private != "2":
+		return "Bad API request, invalid api_paste_private"
+	case paste.Private == "2" && userKey == "":
+		return "Bad API request, invalid api_user_key"
+	}
+	key := s.addPaste(paste)
+	return s.URL + "/" + key
+}
+
+// handleDelete deletes a paste of the user and returns the response body.
+func (s *Server) handleDelete(key, userKey string) string {
+	paste, ok := s.pastes[key]
+	if !ok || paste.Owner != userKey {
+		return "Bad API request, invalid permission to remove paste"
+	}
+	delete(s.pastes, key)
+	return "Paste Removed"
+}
+
+// pasteList returns the list response with up to limit pastes of the user,
+// or of the public pastes if the user key is empty, ordered by their key.
+func (s *Server) pasteList(userKey string, limit int) string {
+	keys := make([]string, 0, len(s.pastes))
+	for key := range s.pastes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var body bytes.Buffer
+	count := 0
+	for _, key := range keys {
+		paste := s.pastes[key]
+		if count >= limit {
+			break
+		}
+		if (userKey == "" && paste.Private != "0") || (userKey != "" && paste.Owner != userKey) {
+			continue
+		}
+		count++
+		fmt.Fprintf(&body, "<paste>\n<paste_key>%s</paste_key>\n<paste_date>%d</paste_date>\n<paste_title>%s</paste_title>\n"+
+			"<paste_size>%d</paste_size>\n<paste_expire_date>0</paste_expire_date>\n<paste_private>%s</paste_private>\n"+
+			"<paste_format_long>%s</paste_format_long>\n<paste_format_short>%s</paste_format_short>\n"+
+			"<paste_url>%s/%s</paste_url>\n<paste_hits>%d</paste_hits>\n</paste>\n",
+			paste.Key, paste.Date, escape(paste.Title), len(paste.Content), paste.Private,
+			escape(paste.Format), escape(paste.Format), s.URL, paste.Key, paste.Hits)
+	}
+	if count == 0 && userKey != "" {
+		return "No pastes found."
+	}
+	return body.String()
+}
+
+// userDetails returns the userdetails response of the user.
+func (s *Server) userDetails() string {
+	return fmt.Sprintf("<user>\n<user_name>%s</user_name>\n<user_format_short>text</user_format_short>\n"+
+		"<user_expiration>N</user_expiration>\n<user_avatar_url>%s/avatar.jpg</user_avatar_url>\n"+
+		"<user_private>1</user_private>\n<user_website></user_website>\n<user_email></user_email>\n"+
+		"<user_location></user_location>\n<user_account_type>%s</user_account_type>\n</user>",
+		escape(s.UserName), s.URL, s.AccountType)
+}
+
+// escape returns the value escaped as XML text.
+func escape(value string) string {
+	var escaped bytes.Buffer
+	_ = xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
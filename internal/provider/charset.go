@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// defaultCharset is the charset of paste content, unless configured otherwise.
+const defaultCharset = "utf-8"
+
+// pasteCharsets are the supported charsets of paste content, by name. Content
+// in UTF-8 is sent and read as is.
+var pasteCharsets = map[string]encoding.Encoding{
+	"utf-8":        nil,
+	"iso-8859-1":   charmap.ISO8859_1,
+	"iso-8859-15":  charmap.ISO8859_15,
+	"windows-1252": charmap.Windows1252,
+}
+
+// pasteCharsetNames are the names of the supported charsets, in the order in
+// which they are listed in validation errors.
+var pasteCharsetNames = []string{"utf-8", "iso-8859-1", "iso-8859-15", "windows-1252"}
+
+// encodeCharset returns the content in the given charset, or an error if the
+// content contains characters that the charset cannot represent.
+func encodeCharset(charset string, content string) (string, error) {
+	enc, ok := pasteCharsets[charset]
+	if !ok {
+		return "", fmt.Errorf("unsupported charset %q", charset)
+	}
+	if enc == nil {
+		return content, nil
+	}
+	encoded, err := enc.NewEncoder().String(content)
+	if err != nil {
+		return "", fmt.Errorf("the content cannot be encoded as %s: %w", charset, err)
+	}
+	return encoded, nil
+}
+
+// decodeCharset returns the content in the given charset as UTF-8.
+func decodeCharset(charset string, content string) (string, error) {
+	enc, ok := pasteCharsets[charset]
+	if !ok {
+		return "", fmt.Errorf("unsupported charset %q", charset)
+	}
+	if enc == nil {
+		return content, nil
+	}
+	return enc.NewDecoder().String(content)
+}
+
+// charsetKey is the context key of the charset of the paste content of the
+// requests made with the context.
+type charsetKey struct{}
+
+// withCharset returns a copy of the context that sets the charset of the paste
+// content of the requests made with it.
+func withCharset(ctx context.Context, charset string) context.Context {
+	return context.WithValue(ctx, charsetKey{}, charset)
+}
+
+// requestCharset returns the charset of the paste content that the context
+// sets, or an empty string if it does not set one.
+func requestCharset(ctx context.Context) string {
+	charset, _ := ctx.Value(charsetKey{}).(string)
+	return charset
+}
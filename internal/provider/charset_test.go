@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestCharsetRoundTrip(t *testing.T) {
+	testCases := map[string]struct {
+		charset string
+		content string
+		encoded string
+	}{
+		"utf-8":        {charset: "utf-8", content: "Café €", encoded: "Café €"},
+		"iso-8859-1":   {charset: "iso-8859-1", content: "Café", encoded: "Caf\xe9"},
+		"iso-8859-15":  {charset: "iso-8859-15", content: "5 €", encoded: "5 \xa4"},
+		"windows-1252": {charset: "windows-1252", content: "5 €", encoded: "5 \x80"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := encodeCharset(testCase.charset, testCase.content)
+			if err != nil {
+				t.Fatalf("unexpected encode error %v", err)
+			}
+			if encoded != testCase.encoded {
+				t.Errorf("expected encoded content %q, got %q", testCase.encoded, encoded)
+			}
+			decoded, err := decodeCharset(testCase.charset, encoded)
+			if err != nil {
+				t.Fatalf("unexpected decode error %v", err)
+			}
+			if decoded != testCase.content {
+				t.Errorf("expected decoded content %q, got %q", testCase.content, decoded)
+			}
+		})
+	}
+}
+
+func TestCharsetErrors(t *testing.T) {
+	if _, err := encodeCharset("iso-8859-1", "5 €"); err == nil {
+		t.Error("expected an error for content that the charset cannot represent")
+	}
+	if _, err := encodeCharset("ebcdic", "Hello"); err == nil {
+		t.Error("expected an error for an unsupported charset")
+	}
+	if _, err := decodeCharset("ebcdic", "Hello"); err == nil {
+		t.Error("expected an error for an unsupported charset")
+	}
+}
+
+func TestPasteCharsetNames(t *testing.T) {
+	if len(pasteCharsetNames) != len(pasteCharsets) {
+		t.Fatalf("expected %d charset names, got %d", len(pasteCharsets), len(pasteCharsetNames))
+	}
+	for _, name := range pasteCharsetNames {
+		if _, ok := pasteCharsets[name]; !ok {
+			t.Errorf("expected charset %q to be supported", name)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &cleanupResource{}
+	_ resource.ResourceWithConfigure        = &cleanupResource{}
+	_ resource.ResourceWithConfigValidators = &cleanupResource{}
+)
+
+// NewCleanupResource is a helper function to simplify the provider implementation.
+func NewCleanupResource() resource.Resource {
+	return &cleanupResource{}
+}
+
+// cleanupResource is the resource implementation.
+type cleanupResource struct {
+	client *pastebinClient
+}
+
+// cleanupResourceModel maps the resource schema data.
+type cleanupResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	OlderThan   types.String `tfsdk:"older_than"`
+	TitlePrefix types.String `tfsdk:"title_prefix"`
+	DryRun      types.Bool   `tfsdk:"dry_run"`
+	DeletedKeys types.List   `tfsdk:"deleted_keys"`
+}
+
+// matches returns whether the paste matches all configured filters at the
+// given time.
+func (m cleanupResourceModel) matches(paste pasteListItem, now time.Time) bool {
+	if !m.TitlePrefix.IsNull() && !strings.HasPrefix(paste.Title, m.TitlePrefix.ValueString()) {
+		return false
+	}
+	if !m.OlderThan.IsNull() {
+		olderThan, err := time.ParseDuration(m.OlderThan.ValueString())
+		if err != nil || now.Sub(time.Unix(paste.Date, 0)) < olderThan {
+			return false
+		}
+	}
+	return true
+}
+
+// Metadata returns the resource type name.
+func (r *cleanupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cleanup"
+}
+
+// Schema defines the schema for the resource.
+func (r *cleanupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			// The pastes are only cleaned up on create, so any change runs the
+			// cleanup again.
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"older_than": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringDuration(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title_prefix": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringNotBlank(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			// A dry run only reports the keys of the matching pastes.
+			"dry_run": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"deleted_keys": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// ConfigValidators returns the validators for the resource configuration.
+func (r *cleanupResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		atLeastOneOf(path.Root("older_than"), path.Root("title_prefix")),
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cleanupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+// Create deletes the matching pastes and sets the initial Terraform state.
+func (r *cleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan cleanupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Listing and deleting pastes requires an authenticated user
+	if r.client.client.UserKey == "" {
+		resp.Diagnostics.Append(missingUserKeyError("Cleaning up the pastes of a user"))
+		return
+	}
+
+	// The list api has no paging, so the maximum number of results is all
+	// that can be cleaned up in a single run
+	pastes, err := r.client.ListPastes(ctx, maxListResults)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Pastebin Pastes",
+			"Could not list the pastes of the user: "+err.Error(),
+		)
+		return
+	}
+	if len(pastes) >= maxListResults {
+		resp.Diagnostics.AddWarning(
+			"Incomplete Pastebin Paste Cleanup",
+			fmt.Sprintf("The user has at least %d pastes, which is the maximum number of pastes the PasteBin API lists. "+
+				"Pastes beyond this limit are not cleaned up until a next run.", maxListResults),
+		)
+	}
+
+	// Delete the matching pastes, of which a paste that is already deleted
+	// counts as deleted, so a failed cleanup can simply run again
+	now := time.Now()
+	deletedKeys := []string{}
+	for _, paste := range pastes {
+		if !plan.matches(paste, now) {
+			continue
+		}
+		if !plan.DryRun.ValueBool() {
+			err := r.client.DeletePaste(ctx, paste.Key)
+			if err != nil && !errors.Is(err, errPasteNotFound) {
+				resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not delete paste %s after deleting %d pastes: %w", paste.Key, len(deletedKeys), err)))
+				return
+			}
+		}
+		deletedKeys = append(deletedKeys, paste.Key)
+	}
+
+	// Map response to schema
+	plan.ID = types.StringValue(now.UTC().Format(time.RFC3339))
+	plan.DeletedKeys, diags = types.ListValueFrom(ctx, types.StringType, deletedKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the Terraform state, as the cleanup has nothing to refresh.
+func (r *cleanupResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update is never called, as every change runs the cleanup again.
+func (r *cleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan cleanupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform state, which leaves the deleted pastes deleted.
+func (r *cleanupResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCleanupResourceValidateConfig(t *testing.T) {
+	testCases := map[string]struct {
+		config    map[string]tftypes.Value
+		expectErr bool
+	}{
+		"older than": {
+			config: map[string]tftypes.Value{
+				"older_than": tftypes.NewValue(tftypes.String, "720h"),
+			},
+		},
+		"title prefix": {
+			config: map[string]tftypes.Value{
+				"title_prefix": tftypes.NewValue(tftypes.String, "ci-"),
+				"dry_run":      tftypes.NewValue(tftypes.Bool, true),
+			},
+		},
+		"no filter": {
+			config:    map[string]tftypes.Value{},
+			expectErr: true,
+		},
+		"invalid older than": {
+			config: map[string]tftypes.Value{
+				"older_than": tftypes.NewValue(tftypes.String, "30 days"),
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diagnostics := testValidateResourceConfig(t, "pastebin_cleanup", testCase.config)
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+		})
+	}
+}
+
+func TestCleanupResourceModelMatches(t *testing.T) {
+	now := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	paste := pasteListItem{
+		Key:   "abcd1234",
+		Title: "ci-build-42",
+		Date:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+
+	testCases := map[string]struct {
+		model    cleanupResourceModel
+		expected bool
+	}{
+		"title prefix": {
+			model:    cleanupResourceModel{TitlePrefix: types.StringValue("ci-"), OlderThan: types.StringNull()},
+			expected: true,
+		},
+		"other title prefix": {
+			model: cleanupResourceModel{TitlePrefix: types.StringValue("release-"), OlderThan: types.StringNull()},
+		},
+		"older": {
+			model:    cleanupResourceModel{TitlePrefix: types.StringNull(), OlderThan: types.StringValue("168h")},
+			expected: true,
+		},
+		"newer": {
+			model: cleanupResourceModel{TitlePrefix: types.StringNull(), OlderThan: types.StringValue("1000h")},
+		},
+		"older with other title prefix": {
+			model: cleanupResourceModel{TitlePrefix: types.StringValue("release-"), OlderThan: types.StringValue("168h")},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if matches := testCase.model.matches(paste, now); matches != testCase.expected {
+				t.Errorf("expected matches %t, got %t", testCase.expected, matches)
+			}
+		})
+	}
+}
@@ -0,0 +1,1013 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/simonkarman/pastebin-client-go"
+)
+
+// pastebinClient wraps the pastebin-client-go client with the request handling
+// and paste options that the provider needs on top of the basic client.
+type pastebinClient struct {
+	client         *pastebin.Client
+	httpClient     *http.Client
+	limiter        *rateLimiter
+	maxInlineBytes int
+
+	// scrapingApiKey authenticates the requests to the scraping api, or is
+	// empty if pastes are not read through the scraping api.
+	scrapingApiKey string
+
+	// basePath is the path of the Pastebin instance on its host, such as
+	// /pastebin, or empty if the instance is at the root of its host.
+	basePath string
+
+	// readCache caches the raw content of pastes, if enabled.
+	readCache *readCache
+
+	// createLock is held while creating a paste, if strict ordering is
+	// enabled, so pastes are created one at a time in the order they are
+	// requested. Unlike a mutex it lets a waiting create be canceled.
+	createLock chan struct{}
+
+	// accountType caches the account type of the user, which is requested
+	// at most once as it is shared by all data sources and resources.
+	accountTypeMutex sync.Mutex
+	accountType      string
+
+	// refreshUserKey fetches a fresh user key when the api rejects the user
+	// key, if configured. The fresh key replaces the configured key.
+	refreshUserKey   func(ctx context.Context) (string, error)
+	userKeyMutex     sync.Mutex
+	refreshedUserKey string
+}
+
+// newPastebinClient creates a new pastebinClient that sends its requests
+// for the given client using the given http client. Creating and deleting
+// pastes is limited by the given rate limiter. Form values larger than
+// maxInlineBytes are streamed instead of buffered.
+func newPastebinClient(client *pastebin.Client, httpClient *http.Client, limiter *rateLimiter, maxInlineBytes int) *pastebinClient {
+	return &pastebinClient{
+		client:         client,
+		httpClient:     httpClient,
+		limiter:        limiter,
+		maxInlineBytes: maxInlineBytes,
+	}
+}
+
+// defaultHost is the host of the Pastebin api, unless configured otherwise.
+const defaultHost = "https://pastebin.com"
+
+// defaultScrapingHost is the host of the scraping api of Pastebin. Pastebin
+// compatible instances serve the scraping api on their own host.
+const defaultScrapingHost = "https://scrape.pastebin.com"
+
+// scrapingApiKeyHeader is the header that authenticates a request to the
+// scraping api.
+const scrapingApiKeyHeader = "X-Scraping-Api-Key"
+
+// The typed errors of the known bad api request messages.
+var (
+	// errPasteNotFound is returned when a paste does not exist or is not accessible.
+	errPasteNotFound     = errors.New("paste not found")
+	errInvalidDevKey     = errors.New("invalid dev key")
+	errInvalidUserKey    = errors.New("invalid user key")
+	errIPBlocked         = errors.New("ip blocked")
+	errPasteLimitReached = errors.New("paste limit reached")
+	errPasteSizeExceeded = errors.New("paste size exceeded")
+	errInvalidParameter  = errors.New("invalid parameter")
+
+	// errScrapingAccessDenied is returned when the scraping api does not
+	// grant access, such as to an IP address that is not whitelisted.
+	errScrapingAccessDenied = errors.New("scraping access denied")
+)
+
+// scrapingErrorMessages maps the known failure messages of the scraping api,
+// by their start, to their typed errors. The scraping api reports failures
+// with a successful status code.
+var scrapingErrorMessages = []struct {
+	prefix string
+	err    error
+}{
+	{"YOUR IP:", errScrapingAccessDenied},
+	{"Error, we cannot find this paste", errPasteNotFound},
+}
+
+// apiErrorMessages maps the known bad api request messages, by a part of the
+// message, to their typed errors.
+var apiErrorMessages = []struct {
+	contains string
+	err      error
+}{
+	{"invalid api_dev_key", errInvalidDevKey},
+	{"invalid api_user_key", errInvalidUserKey},
+	{"account not active", errInvalidUserKey},
+	{"IP blocked", errIPBlocked},
+	{"maximum number of", errPasteLimitReached},
+	{"maximum paste file size exceeded", errPasteSizeExceeded},
+	{"invalid api_paste_key", errPasteNotFound},
+	{"invalid permission to remove paste", errPasteNotFound},
+	{"invalid api_option", errInvalidParameter},
+	{"invalid api_expire_date", errInvalidParameter},
+	{"invalid api_paste_private", errInvalidParameter},
+	{"invalid api_paste_format", errInvalidParameter},
+	{"api_paste_code was empty", errInvalidParameter},
+	{"invalid POST parameters", errInvalidParameter},
+}
+
+// apiErrorHints are the hints to resolve the typed errors.
+var apiErrorHints = map[error]string{
+	errInvalidDevKey:     "Ensure the dev_key value or the PASTEBIN_DEV_KEY environment variable is set to the dev key found at https://pastebin.com/doc_api when logged in.",
+	errInvalidUserKey:    "Ensure the user_key value or the PASTEBIN_USER_KEY environment variable is set to a valid user key of an active account. A user key is generated with the api_login.php api.",
+	errIPBlocked:         "Pastebin blocked the requests from this IP address. Wait before trying again, or lower the requests_per_minute value.",
+	errPasteLimitReached: "The account reached the maximum number of pastes of this visibility. Delete pastes of the account, or upgrade to a Pastebin PRO account.",
+	errPasteSizeExceeded: "The paste exceeds the maximum paste size of the account. Split the content over multiple pastes, or upgrade to a Pastebin PRO account.",
+	errScrapingAccessDenied: "Ensure the scraping_api_key value or the PASTEBIN_SCRAPING_API_KEY environment variable is set to a valid scraping api key, " +
+		"and that the IP address is whitelisted for the scraping api at https://pastebin.com/doc_scraping_api.",
+}
+
+// statusError is returned when the api responds with an unsuccessful status code.
+type statusError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+// Error returns the error message.
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s %s failed [%d] %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// apiError is returned when the api responds with a bad api request message.
+// Known messages unwrap to their typed error.
+type apiError struct {
+	Message string
+	Err     error
+}
+
+// newApiError returns the apiError for the bad api request message.
+func newApiError(message string) *apiError {
+	for _, known := range apiErrorMessages {
+		if strings.Contains(message, known.contains) {
+			return &apiError{Message: message, Err: known.err}
+		}
+	}
+	return &apiError{Message: message}
+}
+
+// Error returns the error message.
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the typed error of the message, or nil if the message is unknown.
+func (e *apiError) Unwrap() error {
+	return e.Err
+}
+
+// apiErrorHint returns a hint to resolve the error, prefixed with an empty
+// line, or an empty string if there is no hint for the error.
+func apiErrorHint(err error) string {
+	for typedErr, hint := range apiErrorHints {
+		if errors.Is(err, typedErr) {
+			return "\n\n" + hint
+		}
+	}
+	return ""
+}
+
+// pasteTooLargeError is returned when the content of a paste exceeds the
+// maximum paste size of the account.
+type pasteTooLargeError struct {
+	Size        int
+	Limit       int
+	AccountType string
+}
+
+// Error returns the error message.
+func (e *pasteTooLargeError) Error() string {
+	return fmt.Sprintf("paste of %d bytes exceeds the maximum paste size of %d bytes for %s accounts", e.Size, e.Limit, e.AccountType)
+}
+
+// Pastebin limits the size of a paste by the account type of the user, guests
+// have the same limit as normal accounts.
+const (
+	maxPasteSize    = 512 * 1024
+	maxProPasteSize = 10 * 1024 * 1024
+)
+
+// pasteOptions holds the optional settings of a paste on creation.
+type pasteOptions struct {
+	Title      string
+	Private    string
+	ExpireDate string
+	Format     string
+	FolderKey  string
+	// Charset is the charset that the content is sent in, or empty for UTF-8.
+	Charset string
+}
+
+// maxPasteTitleLength is the number of characters of a paste title that
+// Pastebin stores, longer titles are truncated.
+const maxPasteTitleLength = 100
+
+// pasteExpireDates are the allowed api_paste_expire_date values.
+var pasteExpireDates = []string{"N", "10M", "1H", "1D", "1W", "2W", "1M", "6M", "1Y"}
+
+// pasteExpireAliases maps the human-friendly expirations, in lower case and
+// without a plural s, to their api_paste_expire_date values.
+var pasteExpireAliases = map[string]string{
+	"never":     "N",
+	"10 minute": "10M",
+	"1 hour":    "1H",
+	"1 day":     "1D",
+	"1 week":    "1W",
+	"2 week":    "2W",
+	"1 month":   "1M",
+	"6 month":   "6M",
+	"1 year":    "1Y",
+}
+
+// normalizeExpire returns the api_paste_expire_date value of an expiration,
+// which is either such a value or a human-friendly alias such as "1 week",
+// and whether the expiration is known.
+func normalizeExpire(expire string) (string, bool) {
+	for _, code := range pasteExpireDates {
+		if expire == code {
+			return code, true
+		}
+	}
+	alias := strings.TrimSuffix(strings.Join(strings.Fields(strings.ToLower(expire)), " "), "s")
+	code, ok := pasteExpireAliases[alias]
+	return code, ok
+}
+
+// expireTime returns when a paste created at the given time expires with an
+// api_paste_expire_date value, and false if it never expires. Months and years
+// are calendar months and years, as on Pastebin.
+func expireTime(created time.Time, expire string) (time.Time, bool) {
+	switch expire {
+	case "10M":
+		return created.Add(10 * time.Minute), true
+	case "1H":
+		return created.Add(time.Hour), true
+	case "1D":
+		return created.AddDate(0, 0, 1), true
+	case "1W":
+		return created.AddDate(0, 0, 7), true
+	case "2W":
+		return created.AddDate(0, 0, 14), true
+	case "1M":
+		return created.AddDate(0, 1, 0), true
+	case "6M":
+		return created.AddDate(0, 6, 0), true
+	case "1Y":
+		return created.AddDate(1, 0, 0), true
+	}
+	return time.Time{}, false
+}
+
+// pasteVisibilities maps the visibility names to their api_paste_private values.
+var pasteVisibilities = map[string]string{
+	"public":   "0",
+	"unlisted": "1",
+	"private":  "2",
+}
+
+// pasteVisibility returns the visibility name of an api_paste_private value.
+func pasteVisibility(private string) string {
+	for visibility, value := range pasteVisibilities {
+		if value == private {
+			return visibility
+		}
+	}
+	return ""
+}
+
+// maxListResults is the maximum api_results_limit of the list api, which does
+// not support paging beyond it.
+const maxListResults = 1000
+
+// pasteListItem is a single paste in the response of the list api.
+type pasteListItem struct {
+	Key         string `xml:"paste_key" json:"paste_key"`
+	Date        int64  `xml:"paste_date" json:"paste_date"`
+	Title       string `xml:"paste_title" json:"paste_title"`
+	Size        int64  `xml:"paste_size" json:"paste_size"`
+	ExpireDate  int64  `xml:"paste_expire_date" json:"paste_expire_date"`
+	Private     string `xml:"paste_private" json:"paste_private"`
+	FormatLong  string `xml:"paste_format_long" json:"paste_format_long"`
+	FormatShort string `xml:"paste_format_short" json:"paste_format_short"`
+	Url         string `xml:"paste_url" json:"paste_url"`
+	Hits        int64  `xml:"paste_hits" json:"paste_hits"`
+}
+
+// userDetails is the response of the userdetails api.
+type userDetails struct {
+	XMLName     xml.Name `xml:"user" json:"-"`
+	Name        string   `xml:"user_name" json:"user_name"`
+	FormatShort string   `xml:"user_format_short" json:"user_format_short"`
+	Expiration  string   `xml:"user_expiration" json:"user_expiration"`
+	AvatarUrl   string   `xml:"user_avatar_url" json:"user_avatar_url"`
+	Private     string   `xml:"user_private" json:"user_private"`
+	Website     string   `xml:"user_website" json:"user_website"`
+	Email       string   `xml:"user_email" json:"user_email"`
+	Location    string   `xml:"user_location" json:"user_location"`
+	AccountType string   `xml:"user_account_type" json:"user_account_type"`
+}
+
+// fetch posts the form data to the path relative to the host of the client
+// and returns the response body.
+func (c *pastebinClient) fetch(ctx context.Context, path string, data url.Values) (string, error) {
+	// Create URL
+	httpUrl := c.url(path)
+
+	// Create Body
+	data.Set("api_dev_key", c.client.DevKey)
+	userKey := c.userKey()
+	if userKey != "" {
+		data.Set("api_user_key", userKey)
+	}
+	respBody, err := c.post(ctx, httpUrl.String(), data)
+
+	// Retry once with a fresh user key if the user key expired
+	if errors.Is(err, errInvalidUserKey) && userKey != "" && c.refreshUserKey != nil {
+		freshUserKey, refreshErr := c.refresh(ctx, userKey)
+		if refreshErr != nil {
+			return "", fmt.Errorf("%w, and refreshing the user key failed: %v", err, refreshErr)
+		}
+		data.Set("api_user_key", freshUserKey)
+		respBody, err = c.post(ctx, httpUrl.String(), data)
+	}
+	return respBody, err
+}
+
+// post posts the form data to the url and returns the response body.
+func (c *pastebinClient) post(ctx context.Context, httpUrl string, data url.Values) (string, error) {
+	getBody, contentLength := formBody(data, c.maxInlineBytes)
+	body, err := getBody()
+	if err != nil {
+		return "", err
+	}
+
+	// Create the request
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, httpUrl, body)
+	if err != nil {
+		return "", err
+	}
+	req.GetBody = getBody
+	req.ContentLength = contentLength
+	contentType := "application/x-www-form-urlencoded"
+	if charset := requestCharset(ctx); charset != "" {
+		contentType += "; charset=" + charset
+		req.Header.Set("Accept-Charset", charset)
+	}
+	req.Header.Add("Content-Type", contentType)
+
+	respBody, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+
+	// Pastebin reports most failures with a successful status code
+	if strings.HasPrefix(respBody, "Bad API request") {
+		return "", newApiError(respBody)
+	}
+	return respBody, nil
+}
+
+// userKey returns the user key to authenticate with, which is the last
+// refreshed user key or the configured user key otherwise.
+func (c *pastebinClient) userKey() string {
+	c.userKeyMutex.Lock()
+	defer c.userKeyMutex.Unlock()
+	if c.refreshedUserKey != "" {
+		return c.refreshedUserKey
+	}
+	return c.client.UserKey
+}
+
+// refresh fetches a fresh user key to replace the expired user key. If
+// another request already replaced the expired key, that key is returned
+// instead of fetching another one.
+func (c *pastebinClient) refresh(ctx context.Context, expiredUserKey string) (string, error) {
+	c.userKeyMutex.Lock()
+	defer c.userKeyMutex.Unlock()
+	if c.refreshedUserKey != "" && c.refreshedUserKey != expiredUserKey {
+		return c.refreshedUserKey, nil
+	}
+	userKey, err := c.refreshUserKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.refreshedUserKey = userKey
+	return userKey, nil
+}
+
+// newUserKeyCommand returns a function that runs the command to fetch a user
+// key, which is the output of the command without surrounding whitespace. An
+// error is returned if the command cannot be found.
+func newUserKeyCommand(command []string) (func(ctx context.Context) (string, error), error) {
+	if len(command) == 0 || command[0] == "" {
+		return nil, errors.New("the command is empty")
+	}
+	name, err := exec.LookPath(command[0])
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context) (string, error) {
+		output, err := exec.CommandContext(ctx, name, command[1:]...).Output()
+		if err != nil {
+			return "", fmt.Errorf("running %s failed: %w", command[0], err)
+		}
+		userKey := strings.TrimSpace(string(output))
+		if userKey == "" {
+			return "", fmt.Errorf("running %s returned no user key", command[0])
+		}
+		return userKey, nil
+	}, nil
+}
+
+// formBody returns a function that creates the form encoded body of the data,
+// and the length of the body. Values larger than maxInlineBytes are encoded
+// while the body is read, so they are not buffered in encoded form, and the
+// length of the body is unknown (-1).
+func formBody(data url.Values, maxInlineBytes int) (func() (io.ReadCloser, error), int64) {
+	inline := url.Values{}
+	streamed := url.Values{}
+	for key, values := range data {
+		for _, value := range values {
+			if len(value) > maxInlineBytes {
+				streamed.Add(key, value)
+			} else {
+				inline.Add(key, value)
+			}
+		}
+	}
+
+	encoded := inline.Encode()
+	if len(streamed) == 0 {
+		return func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(encoded)), nil
+		}, int64(len(encoded))
+	}
+	return func() (io.ReadCloser, error) {
+		reader, writer := io.Pipe()
+		go func() {
+			writer.CloseWithError(writeStreamedForm(writer, encoded, streamed))
+		}()
+		return reader, nil
+	}, -1
+}
+
+// writeStreamedForm writes the encoded form followed by the streamed values,
+// encoding the streamed values in chunks.
+func writeStreamedForm(w io.Writer, encoded string, streamed url.Values) error {
+	const chunkSize = 32 * 1024
+	separator := ""
+	if encoded != "" {
+		if _, err := io.WriteString(w, encoded); err != nil {
+			return err
+		}
+		separator = "&"
+	}
+	for key, values := range streamed {
+		for _, value := range values {
+			if _, err := io.WriteString(w, separator+url.QueryEscape(key)+"="); err != nil {
+				return err
+			}
+			for start := 0; start < len(value); start += chunkSize {
+				end := min(start+chunkSize, len(value))
+				if _, err := io.WriteString(w, url.QueryEscape(value[start:end])); err != nil {
+					return err
+				}
+			}
+			separator = "&"
+		}
+	}
+	return nil
+}
+
+// do executes the request and returns the response body.
+func (c *pastebinClient) do(req *http.Request) (string, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", &statusError{
+			Method:     req.Method,
+			Path:       req.URL.Path,
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+		}
+	}
+	return string(body), nil
+}
+
+// CreatePaste creates a new paste with the given content and options and
+// returns the key of the created paste. The content is sent in the charset of
+// the options.
+func (c *pastebinClient) CreatePaste(ctx context.Context, content string, options pasteOptions) (string, error) {
+	if options.Charset != "" {
+		encoded, err := encodeCharset(options.Charset, content)
+		if err != nil {
+			return "", err
+		}
+		content = encoded
+		ctx = withCharset(ctx, options.Charset)
+	}
+	data := url.Values{
+		"api_option":     {"paste"},
+		"api_paste_code": {content},
+	}
+	if options.Title != "" {
+		data.Set("api_paste_name", options.Title)
+	}
+	if options.Private != "" {
+		data.Set("api_paste_private", options.Private)
+	}
+	if options.Format != "" {
+		data.Set("api_paste_format", options.Format)
+	}
+	if options.ExpireDate != "" {
+		data.Set("api_paste_expire_date", options.ExpireDate)
+	}
+	if options.FolderKey != "" {
+		data.Set("api_folder_key", options.FolderKey)
+	}
+
+	if err := c.checkPasteSize(ctx, len(content)); err != nil {
+		return "", err
+	}
+	if c.createLock != nil {
+		select {
+		case c.createLock <- struct{}{}:
+			defer func() { <-c.createLock }()
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	if err := c.limiter.Wait(ctx); err != nil {
+		return "", err
+	}
+	// A retried create that was processed before it failed would create a
+	// duplicate paste
+	pasteUrl, err := c.fetch(withNonIdempotent(ctx), "/api/api_post.php", data)
+	if err != nil {
+		return "", err
+	}
+	return pasteKeyFromUrl(pasteUrl)
+}
+
+// checkPasteSize returns a pasteTooLargeError if a paste of the given size
+// exceeds the maximum paste size of the account. The account type is only
+// requested for a paste above the limit of a normal account.
+func (c *pastebinClient) checkPasteSize(ctx context.Context, size int) error {
+	if size <= maxPasteSize {
+		return nil
+	}
+	if size > maxProPasteSize {
+		return &pasteTooLargeError{Size: size, Limit: maxProPasteSize, AccountType: "pro"}
+	}
+
+	accountType, err := c.AccountType(ctx)
+	if err != nil {
+		return err
+	}
+	if accountType != "pro" {
+		return &pasteTooLargeError{Size: size, Limit: maxPasteSize, AccountType: accountType}
+	}
+	return nil
+}
+
+// AccountType returns the account type of the user, which is guest without a
+// user key, or normal or pro otherwise. The account type is only requested
+// once and cached afterward.
+func (c *pastebinClient) AccountType(ctx context.Context) (string, error) {
+	if c.client.UserKey == "" {
+		return "guest", nil
+	}
+
+	c.accountTypeMutex.Lock()
+	defer c.accountTypeMutex.Unlock()
+	if c.accountType != "" {
+		return c.accountType, nil
+	}
+	details, err := c.GetUserDetails(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.cacheAccountType(details)
+	return c.accountType, nil
+}
+
+// cacheAccountType caches the account type from the account details of the
+// user. The caller must hold the accountTypeMutex.
+func (c *pastebinClient) cacheAccountType(details *userDetails) {
+	c.accountType = "normal"
+	if details.AccountType == "1" {
+		c.accountType = "pro"
+	}
+}
+
+// GetPaste returns the raw content of the paste with the given key. Without
+// a user key only public and unlisted pastes can be read. The content is
+// cached if the read cache is enabled.
+func (c *pastebinClient) GetPaste(ctx context.Context, pasteKey string) (string, error) {
+	key := readCacheKey{pasteKey: pasteKey, charset: requestCharset(ctx), public: c.client.UserKey == ""}
+	return c.readCache.Get(ctx, key, func() (string, error) {
+		if c.client.UserKey == "" {
+			return c.GetPublicPaste(ctx, pasteKey)
+		}
+
+		return c.fetch(ctx, "/api/api_raw.php", url.Values{
+			"api_option":    {"show_paste"},
+			"api_paste_key": {pasteKey},
+		})
+	})
+}
+
+// GetPublicPaste returns the raw content of the public or unlisted paste with
+// the given key, without authenticating as the user.
+func (c *pastebinClient) GetPublicPaste(ctx context.Context, pasteKey string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.RawPasteUrl(pasteKey), nil)
+	if err != nil {
+		return "", err
+	}
+	if charset := requestCharset(ctx); charset != "" {
+		req.Header.Set("Accept-Charset", charset)
+	}
+
+	content, err := c.do(req)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s", errPasteNotFound, err)
+	}
+	return content, err
+}
+
+// ScrapePaste returns the raw content of the public or unlisted paste with
+// the given key through the scraping api of Pastebin PRO.
+func (c *pastebinClient) ScrapePaste(ctx context.Context, pasteKey string) (string, error) {
+	scrapeUrl := c.scrapingUrl("/api_scrape_item.php")
+	scrapeUrl.RawQuery = url.Values{"i": {pasteKey}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scrapeUrl.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(scrapingApiKeyHeader, c.scrapingApiKey)
+
+	content, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	for _, known := range scrapingErrorMessages {
+		if strings.HasPrefix(content, known.prefix) {
+			return "", fmt.Errorf("%w: %s", known.err, strings.TrimSpace(content))
+		}
+	}
+	return content, nil
+}
+
+// DeletePaste deletes the paste with the given key. Pastebin reports a paste
+// that is already deleted or expired the same way as a paste of another user.
+func (c *pastebinClient) DeletePaste(ctx context.Context, pasteKey string) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	c.readCache.Forget(pasteKey)
+	_, err := c.fetch(ctx, "/api/api_post.php", url.Values{
+		"api_option":    {"delete"},
+		"api_paste_key": {pasteKey},
+	})
+	return err
+}
+
+// ListPastes returns up to limit pastes of the user.
+func (c *pastebinClient) ListPastes(ctx context.Context, limit int) ([]pasteListItem, error) {
+	body, err := c.fetch(ctx, "/api/api_post.php", url.Values{
+		"api_option":        {"list"},
+		"api_results_limit": {fmt.Sprint(limit)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parsePasteList(body)
+}
+
+// ListTrendingPastes returns the currently trending public pastes.
+func (c *pastebinClient) ListTrendingPastes(ctx context.Context) ([]pasteListItem, error) {
+	body, err := c.fetch(ctx, "/api/api_post.php", url.Values{
+		"api_option": {"trends"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parsePasteList(body)
+}
+
+// FindPaste returns the paste with the given key from the pastes of the user,
+// or nil if the user has no paste with that key.
+func (c *pastebinClient) FindPaste(ctx context.Context, pasteKey string) (*pasteListItem, error) {
+	pastes, err := c.ListPastes(ctx, maxListResults)
+	if err != nil {
+		return nil, err
+	}
+	for _, paste := range pastes {
+		if paste.Key == pasteKey {
+			return &paste, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetUserDetails returns the account details of the user.
+func (c *pastebinClient) GetUserDetails(ctx context.Context) (*userDetails, error) {
+	body, err := c.fetch(ctx, "/api/api_post.php", url.Values{
+		"api_option": {"userdetails"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var details userDetails
+	if err := parseResponse(body, "", &details); err != nil {
+		return nil, newParseError("user details", body, err)
+	}
+	return &details, nil
+}
+
+// VerifyConnection verifies that the host speaks the Pastebin api by requesting
+// the account details of the user. Without a user key the api is expected to
+// reject the request for an invalid user key.
+func (c *pastebinClient) VerifyConnection(ctx context.Context) error {
+	details, err := c.GetUserDetails(ctx)
+	if c.client.UserKey == "" && errors.Is(err, errInvalidUserKey) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if details.Name == "" {
+		return errors.New("unexpected response without user details")
+	}
+
+	// Save a request for the account type later on
+	c.accountTypeMutex.Lock()
+	defer c.accountTypeMutex.Unlock()
+	c.cacheAccountType(details)
+	return nil
+}
+
+// parsePasteList parses the <paste> fragments returned by the list api, or a
+// JSON array of pastes as returned by some Pastebin compatible instances.
+func parsePasteList(body string) ([]pasteListItem, error) {
+	if strings.HasPrefix(cleanResponse(body), "No pastes found") {
+		return []pasteListItem{}, nil
+	}
+	var list struct {
+		Pastes []pasteListItem `xml:"paste"`
+	}
+	if err := parseResponse(body, "pastes", &list); err != nil {
+		return nil, newParseError("paste list", body, err)
+	}
+
+	// The lenient parser accepts any markup, such as an error page, of which
+	// no pastes are a sign. No pastes are otherwise an empty response.
+	if list.Pastes == nil && cleanResponse(body) != "" && !isJsonResponse(body) {
+		return nil, newParseError("paste list", body, errors.New("no <paste> elements found"))
+	}
+	if list.Pastes == nil {
+		return []pasteListItem{}, nil
+	}
+	return list.Pastes, nil
+}
+
+// parseResponse parses the XML or JSON response body of the api into v. The
+// XML fragments are wrapped in an element with the given name, if any, and a
+// JSON array is wrapped in an object with a field of that name. The
+// XML is parsed leniently, as Pastebin does not always escape the titles.
+func parseResponse(body string, wrapper string, v interface{}) error {
+	body = cleanResponse(body)
+	if isJsonResponse(body) {
+		if wrapper != "" && strings.HasPrefix(body, "[") {
+			body = `{"` + wrapper + `":` + body + `}`
+		}
+		return json.Unmarshal([]byte(body), v)
+	}
+
+	// A wrapper element goes after the XML declaration, if any
+	prolog := ""
+	if strings.HasPrefix(body, "<?xml") {
+		if end := strings.Index(body, "?>"); end >= 0 {
+			prolog, body = body[:end+2], body[end+2:]
+		}
+	}
+	if wrapper != "" {
+		body = "<" + wrapper + ">" + body + "</" + wrapper + ">"
+	}
+	decoder := xml.NewDecoder(strings.NewReader(prolog + body))
+	decoder.Strict = false
+	decoder.CharsetReader = charsetReader
+	return decoder.Decode(v)
+}
+
+// isJsonResponse returns whether the response body is JSON instead of XML.
+func isJsonResponse(body string) bool {
+	body = cleanResponse(body)
+	return strings.HasPrefix(body, "{") || strings.HasPrefix(body, "[")
+}
+
+// cleanResponse returns the response body without the byte order mark and
+// surrounding whitespace that some Pastebin compatible instances add.
+func cleanResponse(body string) string {
+	return strings.TrimSpace(strings.TrimPrefix(body, "\ufeff"))
+}
+
+// charsetReader converts an XML response in a charset other than UTF-8 to
+// UTF-8, of which only ASCII and Latin-1 are supported.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "latin1", "latin-1":
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return strings.NewReader(string(runes)), nil
+	}
+	return nil, fmt.Errorf("unsupported charset %q", charset)
+}
+
+// maxSnippetLength is the maximum length of the response body included in a
+// parseError.
+const maxSnippetLength = 200
+
+// parseError is returned when a response body of the api cannot be parsed.
+type parseError struct {
+	What string
+	Body string
+	Err  error
+}
+
+// newParseError returns the parseError of a response body with the
+// description of what it contains.
+func newParseError(what string, body string, err error) *parseError {
+	return &parseError{What: what, Body: body, Err: err}
+}
+
+// Error returns the error message, which includes the start of the body.
+func (e *parseError) Error() string {
+	snippet := strings.TrimSpace(e.Body)
+	if len(snippet) > maxSnippetLength {
+		snippet = strings.ToValidUTF8(snippet[:maxSnippetLength], "") + "..."
+	}
+	return fmt.Sprintf("unable to parse %s: %s, in response %q", e.What, e.Err, snippet)
+}
+
+// Unwrap returns the error of the parser.
+func (e *parseError) Unwrap() error {
+	return e.Err
+}
+
+// PasteUrl returns the url of the paste with the given key.
+func (c *pastebinClient) PasteUrl(pasteKey string) string {
+	return c.url("/" + pasteKey).String()
+}
+
+// RawPasteUrl returns the url of the raw content of the paste with the given key.
+func (c *pastebinClient) RawPasteUrl(pasteKey string) string {
+	return c.url("/raw/" + pasteKey).String()
+}
+
+// scrapingUrl returns the url of the path of the scraping api, which is on a
+// separate host for Pastebin itself.
+func (c *pastebinClient) scrapingUrl(path string) *url.URL {
+	if strings.TrimSuffix(c.client.Host.String(), "/") == defaultHost {
+		scrapingHost, _ := url.Parse(defaultScrapingHost)
+		return scrapingHost.ResolveReference(&url.URL{Path: path})
+	}
+	return c.url(path)
+}
+
+// url returns the url of the path on the host of the client, below the base
+// path of the client.
+func (c *pastebinClient) url(path string) *url.URL {
+	return c.client.Host.ResolveReference(&url.URL{Path: joinBasePath(c.basePath, path)})
+}
+
+// joinBasePath joins the base path and the path into an absolute path, with
+// a single slash in between regardless of the slashes around either.
+func joinBasePath(basePath, path string) string {
+	basePath = strings.Trim(basePath, "/")
+	path = strings.TrimLeft(path, "/")
+	if basePath == "" {
+		return "/" + path
+	}
+	return "/" + basePath + "/" + path
+}
+
+// rawPasteUrl returns the url of the raw content of the paste with the given
+// key on the given host.
+func rawPasteUrl(host url.URL, pasteKey string) string {
+	return host.ResolveReference(&url.URL{Path: "/raw/" + pasteKey}).String()
+}
+
+// pasteKeyFromUrl extracts the paste key from a paste url such as
+// https://pastebin.com/abcd1234. The key is the last segment of the path, so
+// an instance with a base path returns the key without it.
+func pasteKeyFromUrl(pasteUrl string) (string, error) {
+	parsedUrl, err := url.Parse(strings.TrimSpace(pasteUrl))
+	if err != nil {
+		return "", err
+	}
+	pasteKey := strings.Trim(parsedUrl.Path, "/")
+	pasteKey = pasteKey[strings.LastIndex(pasteKey, "/")+1:]
+	if pasteKey == "" {
+		return "", fmt.Errorf("no paste key in response %q", pasteUrl)
+	}
+	return pasteKey, nil
+}
+
+// pasteKeyLength is the number of characters of a paste key.
+const pasteKeyLength = 8
+
+// validatePasteKey returns an error that describes why the value is not a
+// paste key, which consists of 8 alphanumeric characters.
+func validatePasteKey(value string) error {
+	length := 0
+	for _, r := range value {
+		length++
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return fmt.Errorf("paste key %q contains the invalid character %q at position %d, only letters and digits are allowed", value, r, length)
+		}
+	}
+	if length != pasteKeyLength {
+		return fmt.Errorf("paste key %q is %d characters long, but must be %d characters long", value, length, pasteKeyLength)
+	}
+	return nil
+}
+
+// parsePasteKey returns the paste key of a value that is either a paste key,
+// or a paste url such as https://pastebin.com/abcd1234 or its raw url, from
+// which the last path segment is the key.
+func parsePasteKey(value string) (string, error) {
+	pasteKey := value
+	if strings.Contains(value, "/") {
+		parsedUrl, err := url.Parse(value)
+		if err != nil {
+			return "", fmt.Errorf("paste url %q is not a valid url: %w", value, err)
+		}
+		segments := strings.Split(strings.TrimSuffix(parsedUrl.Path, "/"), "/")
+		pasteKey = segments[len(segments)-1]
+	}
+	if err := validatePasteKey(pasteKey); err != nil {
+		return "", err
+	}
+	return pasteKey, nil
+}
+
+// pasteUrlHost returns the host name of a paste url, which may omit its
+// scheme, or an empty string for a paste key or a url without a host.
+func pasteUrlHost(value string) string {
+	if !strings.Contains(value, "/") {
+		return ""
+	}
+	if !strings.Contains(value, "://") {
+		value = "//" + value
+	}
+	parsedUrl, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+	return parsedUrl.Hostname()
+}
+
+// sameHost returns whether the host names are the same, ignoring case and a
+// www subdomain.
+func sameHost(a, b string) bool {
+	return strings.TrimPrefix(strings.ToLower(a), "www.") == strings.TrimPrefix(strings.ToLower(b), "www.")
+}
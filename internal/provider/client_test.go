@@ -0,0 +1,755 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/simonkarman/pastebin-client-go"
+)
+
+// newTestPastebinClient creates a pastebinClient that sends its requests to
+// the given test server.
+func newTestPastebinClient(t *testing.T, server *httptest.Server) *pastebinClient {
+	t.Helper()
+	host, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newPastebinClient(pastebin.New(*host, "dev", "user"), server.Client(), nil, 1024)
+}
+
+func TestPastebinClientCreatePaste(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/api_post.php" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]string{
+			"api_option":            "paste",
+			"api_paste_code":        "hello",
+			"api_paste_name":        "greeting",
+			"api_paste_private":     "2",
+			"api_paste_expire_date": "1D",
+			"api_dev_key":           "dev",
+			"api_user_key":          "user",
+		}
+		for key, value := range expected {
+			if r.PostForm.Get(key) != value {
+				t.Errorf("expected %s to be %q, got %q", key, value, r.PostForm.Get(key))
+			}
+		}
+		_, _ = w.Write([]byte("https://pastebin.com/abcd1234"))
+	}))
+	defer server.Close()
+
+	pasteKey, err := newTestPastebinClient(t, server).CreatePaste(context.Background(), "hello", pasteOptions{
+		Title:      "greeting",
+		Private:    "2",
+		ExpireDate: "1D",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pasteKey != "abcd1234" {
+		t.Errorf("expected paste key abcd1234, got %q", pasteKey)
+	}
+}
+
+func TestPastebinClientCreatePasteStrictOrdering(t *testing.T) {
+	testCases := map[string]struct {
+		strictOrdering bool
+	}{
+		"strict":     {strictOrdering: true},
+		"concurrent": {strictOrdering: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var events []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				events = append(events, "start")
+				mu.Unlock()
+				time.Sleep(50 * time.Millisecond)
+				mu.Lock()
+				events = append(events, "end")
+				mu.Unlock()
+				_, _ = w.Write([]byte("https://pastebin.com/abcd1234"))
+			}))
+			defer server.Close()
+
+			client := newTestPastebinClient(t, server)
+			if testCase.strictOrdering {
+				client.createLock = make(chan struct{}, 1)
+			}
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := client.CreatePaste(context.Background(), "hello", pasteOptions{}); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			// Creates that are strictly ordered each end before the next starts
+			overlapped := false
+			for i := 1; i < len(events); i++ {
+				if events[i] == events[i-1] {
+					overlapped = true
+				}
+			}
+			if overlapped == testCase.strictOrdering {
+				t.Errorf("expected overlapping creates %t, got %v", !testCase.strictOrdering, events)
+			}
+		})
+	}
+}
+
+func TestPastebinClientCreatePasteStrictOrderingCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("expected no request while another create is in progress")
+	}))
+	defer server.Close()
+
+	// Another create holds the lock until the context is done
+	client := newTestPastebinClient(t, server)
+	client.createLock = make(chan struct{}, 1)
+	client.createLock <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.CreatePaste(ctx, "hello", pasteOptions{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestPastebinClientGetPaste(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/api_raw.php" || r.FormValue("api_paste_key") != "abcd1234" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.PostForm)
+		}
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	content, err := newTestPastebinClient(t, server).GetPaste(context.Background(), "abcd1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != "hello" {
+		t.Errorf("expected content hello, got %q", content)
+	}
+}
+
+func TestPastebinClientGetPasteCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Cancel while the request is in flight and hold the response until
+		// the client gave up on it
+		cancel()
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	_, err := newTestPastebinClient(t, server).GetPaste(ctx, "abcd1234")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the request to be canceled, got %v", err)
+	}
+	diagnostic := buildDiagnostic(classifyError(err), fmt.Errorf("could not read paste: %w", err))
+	if diagnostic.Summary() != "PasteBin API Request Canceled" || !strings.Contains(diagnostic.Detail(), "context canceled") {
+		t.Errorf("unexpected diagnostic %q: %q", diagnostic.Summary(), diagnostic.Detail())
+	}
+}
+
+func TestPastebinClientGetPasteCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.FormValue("api_option") == "show_paste" {
+			_, _ = w.Write([]byte("hello"))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestPastebinClient(t, server)
+	client.readCache = newReadCache()
+	for i := 0; i < 2; i++ {
+		if content, err := client.GetPaste(context.Background(), "abcd1234"); err != nil || content != "hello" {
+			t.Fatalf("expected content hello, got %q (%v)", content, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the paste to be requested once, got %d requests", requests)
+	}
+
+	// A read in another charset is not served from the cache
+	if _, err := client.GetPaste(withCharset(context.Background(), "iso-8859-1"), "abcd1234"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("expected the paste to be requested again in another charset, got %d requests", requests)
+	}
+
+	// A deleted paste is no longer served from the cache
+	if err := client.DeletePaste(context.Background(), "abcd1234"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetPaste(context.Background(), "abcd1234"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 4 {
+		t.Errorf("expected the deleted paste to be requested again, got %d requests", requests)
+	}
+}
+
+func TestPastebinClientBadApiRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("Bad API request, invalid api_dev_key"))
+	}))
+	defer server.Close()
+
+	err := newTestPastebinClient(t, server).DeletePaste(context.Background(), "abcd1234")
+	if err == nil || err.Error() != "Bad API request, invalid api_dev_key" {
+		t.Errorf("expected bad api request error, got %v", err)
+	}
+}
+
+func TestNewApiError(t *testing.T) {
+	testCases := map[string]error{
+		"Bad API request, invalid api_dev_key":                                            errInvalidDevKey,
+		"Bad API request, invalid api_user_key":                                           errInvalidUserKey,
+		"Bad API request, account not active":                                             errInvalidUserKey,
+		"Bad API request, IP blocked":                                                     errIPBlocked,
+		"Bad API request, maximum number of 25 unlisted pastes for your free account":     errPasteLimitReached,
+		"Bad API request, maximum paste file size exceeded":                               errPasteSizeExceeded,
+		"Bad API request, invalid permission to view this paste or invalid api_paste_key": errPasteNotFound,
+		"Bad API request, invalid permission to remove paste":                             errPasteNotFound,
+		"Bad API request, invalid api_option":                                             errInvalidParameter,
+		"Bad API request, something new":                                                  nil,
+	}
+
+	for message, expected := range testCases {
+		t.Run(message, func(t *testing.T) {
+			err := newApiError(message)
+			if err.Error() != message {
+				t.Errorf("expected message %q, got %q", message, err.Error())
+			}
+			if err.Unwrap() != expected {
+				t.Errorf("expected typed error %v, got %v", expected, err.Unwrap())
+			}
+		})
+	}
+}
+
+func TestApiErrorHint(t *testing.T) {
+	if hint := apiErrorHint(newApiError("Bad API request, invalid api_dev_key")); !strings.Contains(hint, "dev_key") {
+		t.Errorf("expected a hint about the dev key, got %q", hint)
+	}
+	if hint := apiErrorHint(newApiError("Bad API request, something new")); hint != "" {
+		t.Errorf("expected no hint for an unknown message, got %q", hint)
+	}
+}
+
+func TestParsePasteList(t *testing.T) {
+	pastes, err := parsePasteList(`<paste>
+<paste_key>0b42rwhf</paste_key>
+<paste_date>1297953260</paste_date>
+<paste_title>javascript test</paste_title>
+<paste_size>15</paste_size>
+<paste_expire_date>1297956860</paste_expire_date>
+<paste_private>1</paste_private>
+<paste_format_long>JavaScript</paste_format_long>
+<paste_format_short>javascript</paste_format_short>
+<paste_url>https://pastebin.com/0b42rwhf</paste_url>
+<paste_hits>15</paste_hits>
+</paste>
+<paste>
+<paste_key>0C343n0d</paste_key>
+<paste_date>1297694343</paste_date>
+<paste_title>Welcome To Pastebin V3</paste_title>
+<paste_size>490</paste_size>
+<paste_expire_date>0</paste_expire_date>
+<paste_private>0</paste_private>
+<paste_format_long>None</paste_format_long>
+<paste_format_short>text</paste_format_short>
+<paste_url>https://pastebin.com/0C343n0d</paste_url>
+<paste_hits>65</paste_hits>
+</paste>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pastes) != 2 {
+		t.Fatalf("expected 2 pastes, got %d", len(pastes))
+	}
+	if pastes[0].Key != "0b42rwhf" || pastes[0].Title != "javascript test" || pastes[0].FormatShort != "javascript" {
+		t.Errorf("unexpected first paste %+v", pastes[0])
+	}
+	if pasteVisibility(pastes[0].Private) != "unlisted" || pasteVisibility(pastes[1].Private) != "public" {
+		t.Errorf("unexpected visibilities %q and %q", pastes[0].Private, pastes[1].Private)
+	}
+
+	pastes, err = parsePasteList("No pastes found.")
+	if err != nil || len(pastes) != 0 {
+		t.Errorf("expected no pastes, got %v (%v)", pastes, err)
+	}
+}
+
+func TestParsePasteListVariations(t *testing.T) {
+	testCases := map[string]string{
+		"byte order mark and whitespace": "\ufeff\n  <paste><paste_key>0b42rwhf</paste_key><paste_title>javascript test</paste_title></paste>\n\n",
+		"xml declaration":                `<?xml version="1.0" encoding="UTF-8"?><paste><paste_key>0b42rwhf</paste_key><paste_title>javascript test</paste_title></paste>`,
+		"latin-1":                        "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><paste><paste_key>0b42rwhf</paste_key><paste_title>javascript test</paste_title></paste>",
+		"unescaped ampersand":            `<paste><paste_key>0b42rwhf</paste_key><paste_title>javascript test</paste_title><paste_format_long>C & C++</paste_format_long></paste>`,
+		"json array":                     `[{"paste_key": "0b42rwhf", "paste_date": 1297953260, "paste_title": "javascript test", "paste_private": "1"}]`,
+		"json object":                    `{"pastes": [{"paste_key": "0b42rwhf", "paste_title": "javascript test"}]}`,
+	}
+
+	for name, body := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pastes, err := parsePasteList(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pastes) != 1 || pastes[0].Key != "0b42rwhf" || pastes[0].Title != "javascript test" {
+				t.Errorf("unexpected pastes %+v", pastes)
+			}
+		})
+	}
+}
+
+func TestParsePasteListError(t *testing.T) {
+	body := "<html><body>" + strings.Repeat("Service Unavailable ", 50) + "</body></html>"
+	_, err := parsePasteList(body)
+	var parseErr *parseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+	if message := err.Error(); !strings.Contains(message, "unable to parse paste list") || !strings.Contains(message, "<html><body>Service Unavailable") || len(message) > 2*maxSnippetLength+100 {
+		t.Errorf("expected a truncated snippet of the body, got %q", message)
+	}
+}
+
+func TestPastebinClientGetUserDetailsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<html><body>Service Unavailable</body></html>"))
+	}))
+	defer server.Close()
+
+	_, err := newTestPastebinClient(t, server).GetUserDetails(context.Background())
+	var parseErr *parseError
+	if !errors.As(err, &parseErr) || !strings.Contains(err.Error(), "Service Unavailable") {
+		t.Errorf("expected a parse error with the body, got %v", err)
+	}
+}
+
+func TestPastebinClientGetUserDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("api_option") != "userdetails" {
+			t.Errorf("unexpected api option %q", r.FormValue("api_option"))
+		}
+		_, _ = w.Write([]byte(`<user>
+<user_name>wiz_kitty</user_name>
+<user_format_short>text</user_format_short>
+<user_expiration>N</user_expiration>
+<user_avatar_url>https://pastebin.com/cache/a/1.jpg</user_avatar_url>
+<user_private>1</user_private>
+<user_website>https://myawesomesite.com</user_website>
+<user_email>oh@dear.com</user_email>
+<user_location>New York</user_location>
+<user_account_type>1</user_account_type>
+</user>`))
+	}))
+	defer server.Close()
+
+	details, err := newTestPastebinClient(t, server).GetUserDetails(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.Name != "wiz_kitty" || details.Private != "1" || details.AccountType != "1" || details.Location != "New York" {
+		t.Errorf("unexpected user details %+v", details)
+	}
+}
+
+func TestPastebinClientPasteUrls(t *testing.T) {
+	host, err := url.Parse("https://pastebin.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newPastebinClient(pastebin.New(*host, "dev", "user"), http.DefaultClient, nil, 1024)
+	if pasteUrl := client.PasteUrl("abcd1234"); pasteUrl != "https://pastebin.com/abcd1234" {
+		t.Errorf("unexpected paste url %q", pasteUrl)
+	}
+	if rawUrl := client.RawPasteUrl("abcd1234"); rawUrl != "https://pastebin.com/raw/abcd1234" {
+		t.Errorf("unexpected raw paste url %q", rawUrl)
+	}
+	if scrapingUrl := client.scrapingUrl("/api_scrape_item.php"); scrapingUrl.String() != "https://scrape.pastebin.com/api_scrape_item.php" {
+		t.Errorf("unexpected scraping url %q", scrapingUrl)
+	}
+}
+
+func TestPastebinClientBasePath(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pastebin/api/api_raw.php":
+			_, _ = w.Write([]byte("hello"))
+		case "/pastebin/api/api_post.php":
+			_, _ = w.Write([]byte(server.URL + "/pastebin/abcd1234"))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestPastebinClient(t, server)
+	client.basePath = "/pastebin/"
+	if _, err := client.GetPaste(context.Background(), "abcd1234"); err != nil {
+		t.Fatal(err)
+	}
+	pasteKey, err := client.CreatePaste(context.Background(), "hello", pasteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pasteKey != "abcd1234" {
+		t.Errorf("expected paste key abcd1234, got %q", pasteKey)
+	}
+	if pasteUrl := client.PasteUrl("abcd1234"); pasteUrl != server.URL+"/pastebin/abcd1234" {
+		t.Errorf("unexpected paste url %q", pasteUrl)
+	}
+	if rawUrl := client.RawPasteUrl("abcd1234"); rawUrl != server.URL+"/pastebin/raw/abcd1234" {
+		t.Errorf("unexpected raw paste url %q", rawUrl)
+	}
+	if scrapingUrl := client.scrapingUrl("/api_scrape_item.php"); scrapingUrl.String() != server.URL+"/pastebin/api_scrape_item.php" {
+		t.Errorf("unexpected scraping url %q", scrapingUrl)
+	}
+}
+
+func TestJoinBasePath(t *testing.T) {
+	testCases := []struct {
+		basePath string
+		path     string
+		expected string
+	}{
+		{basePath: "", path: "/api/api_post.php", expected: "/api/api_post.php"},
+		{basePath: "/", path: "/api/api_post.php", expected: "/api/api_post.php"},
+		{basePath: "/pastebin", path: "/api/api_post.php", expected: "/pastebin/api/api_post.php"},
+		{basePath: "/pastebin/", path: "/api/api_post.php", expected: "/pastebin/api/api_post.php"},
+		{basePath: "pastebin", path: "api/api_post.php", expected: "/pastebin/api/api_post.php"},
+		{basePath: "tools/pastebin/", path: "raw/abcd1234", expected: "/tools/pastebin/raw/abcd1234"},
+	}
+
+	for _, testCase := range testCases {
+		if joined := joinBasePath(testCase.basePath, testCase.path); joined != testCase.expected {
+			t.Errorf("expected %q and %q to join to %q, got %q", testCase.basePath, testCase.path, testCase.expected, joined)
+		}
+	}
+}
+
+func TestNormalizeExpire(t *testing.T) {
+	testCases := map[string]string{
+		"N":          "N",
+		"1W":         "1W",
+		"never":      "N",
+		"Never":      "N",
+		"10 minutes": "10M",
+		"1 hour":     "1H",
+		"1 day":      "1D",
+		"1 week":     "1W",
+		"2 weeks":    "2W",
+		"1 month":    "1M",
+		"6 months":   "6M",
+		"1 year":     "1Y",
+		" 1  Day ":   "1D",
+	}
+
+	for expire, expected := range testCases {
+		if code, ok := normalizeExpire(expire); !ok || code != expected {
+			t.Errorf("expected %q to normalize to %q, got %q (known %t)", expire, expected, code, ok)
+		}
+	}
+
+	for _, expire := range []string{"", "1w", "2 days", "1 decade", "forever"} {
+		if code, ok := normalizeExpire(expire); ok {
+			t.Errorf("expected %q to be unknown, got %q", expire, code)
+		}
+	}
+}
+
+func TestExpireTime(t *testing.T) {
+	created := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	testCases := map[string]time.Time{
+		"10M": time.Date(2024, 1, 31, 12, 10, 0, 0, time.UTC),
+		"1H":  time.Date(2024, 1, 31, 13, 0, 0, 0, time.UTC),
+		"1D":  time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+		"1W":  time.Date(2024, 2, 7, 12, 0, 0, 0, time.UTC),
+		"2W":  time.Date(2024, 2, 14, 12, 0, 0, 0, time.UTC),
+		"1M":  time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC),
+		"6M":  time.Date(2024, 7, 31, 12, 0, 0, 0, time.UTC),
+		"1Y":  time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC),
+	}
+
+	for expire, expected := range testCases {
+		if expires, ok := expireTime(created, expire); !ok || !expires.Equal(expected) {
+			t.Errorf("expected %q to expire at %s, got %s (expires %t)", expire, expected, expires, ok)
+		}
+	}
+
+	for _, expire := range []string{"N", ""} {
+		if expires, ok := expireTime(created, expire); ok {
+			t.Errorf("expected %q to never expire, got %s", expire, expires)
+		}
+	}
+}
+
+func TestPastebinClientGetPasteNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("Bad API request, invalid permission to view this paste or invalid api_paste_key"))
+	}))
+	defer server.Close()
+
+	_, err := newTestPastebinClient(t, server).GetPaste(context.Background(), "abcd1234")
+	if !errors.Is(err, errPasteNotFound) {
+		t.Errorf("expected paste not found error, got %v", err)
+	}
+}
+
+func TestPastebinClientGetPublicPaste(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		if r.URL.Path != "/raw/abcd1234" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	client := newTestPastebinClient(t, server)
+	content, err := client.GetPublicPaste(context.Background(), "abcd1234")
+	if err != nil || content != "hello" {
+		t.Errorf("expected content hello, got %q (%v)", content, err)
+	}
+	_, err = client.GetPublicPaste(context.Background(), "missing1")
+	if !errors.Is(err, errPasteNotFound) {
+		t.Errorf("expected paste not found error, got %v", err)
+	}
+}
+
+func TestPastebinClientCreateGuestPaste(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := r.PostForm["api_user_key"]; ok {
+			t.Error("expected no user key for a guest paste")
+		}
+		_, _ = w.Write([]byte("https://pastebin.com/abcd1234"))
+	}))
+	defer server.Close()
+
+	client := newTestPastebinClient(t, server)
+	client.client.UserKey = ""
+	pasteKey, err := client.CreatePaste(context.Background(), "hello", pasteOptions{Private: "1"})
+	if err != nil || pasteKey != "abcd1234" {
+		t.Errorf("expected paste key abcd1234, got %q (%v)", pasteKey, err)
+	}
+}
+
+func TestPastebinClientListTrendingPastes(t *testing.T) {
+	testCases := map[string]struct {
+		body     string
+		expected []string
+	}{
+		"trending": {
+			body:     "<paste><paste_key>abcd1234</paste_key><paste_hits>15</paste_hits></paste>\r\n<paste><paste_key>efgh5678</paste_key></paste>",
+			expected: []string{"abcd1234", "efgh5678"},
+		},
+		"empty": {
+			body:     "",
+			expected: []string{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.FormValue("api_option") != "trends" {
+					t.Errorf("unexpected api option %q", r.FormValue("api_option"))
+				}
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			pastes, err := newTestPastebinClient(t, server).ListTrendingPastes(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pastes) != len(testCase.expected) {
+				t.Fatalf("expected %d pastes, got %d", len(testCase.expected), len(pastes))
+			}
+			for i, key := range testCase.expected {
+				if pastes[i].Key != key {
+					t.Errorf("expected paste %d to have key %s, got %s", i, key, pastes[i].Key)
+				}
+			}
+		})
+	}
+}
+
+func TestPastebinClientVerifyConnection(t *testing.T) {
+	testCases := map[string]struct {
+		body      string
+		userKey   string
+		expectErr bool
+	}{
+		"user details": {
+			body:    "<user><user_name>karman</user_name></user>",
+			userKey: "user",
+		},
+		"invalid user key": {
+			body:      "Bad API request, invalid api_user_key",
+			userKey:   "user",
+			expectErr: true,
+		},
+		"guest": {
+			body: "Bad API request, invalid api_user_key",
+		},
+		"unexpected body": {
+			body:      "<html><body>Welcome to nginx!</body></html>",
+			userKey:   "user",
+			expectErr: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			client := newTestPastebinClient(t, server)
+			client.client.UserKey = testCase.userKey
+			err := client.VerifyConnection(context.Background())
+			if testCase.expectErr != (err != nil) {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestPastebinClientCreateStreamedPaste(t *testing.T) {
+	content := strings.Repeat("hello & goodbye\n", 4096)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.PostForm.Get("api_paste_code") != content || r.PostForm.Get("api_option") != "paste" {
+			t.Errorf("unexpected streamed form %v", r.PostForm)
+		}
+		_, _ = w.Write([]byte("https://pastebin.com/abcd1234"))
+	}))
+	defer server.Close()
+
+	pasteKey, err := newTestPastebinClient(t, server).CreatePaste(context.Background(), content, pasteOptions{})
+	if err != nil || pasteKey != "abcd1234" {
+		t.Errorf("expected paste key abcd1234, got %q (%v)", pasteKey, err)
+	}
+}
+
+func TestPastebinClientCreatePasteTooLarge(t *testing.T) {
+	testCases := map[string]struct {
+		size        int
+		userKey     string
+		accountType string
+		expectErr   bool
+	}{
+		"normal size":       {size: maxPasteSize, userKey: "user", accountType: "0"},
+		"normal account":    {size: maxPasteSize + 1, userKey: "user", accountType: "0", expectErr: true},
+		"guest":             {size: maxPasteSize + 1, expectErr: true},
+		"pro account":       {size: maxPasteSize + 1, userKey: "user", accountType: "1"},
+		"pro account limit": {size: maxProPasteSize + 1, userKey: "user", accountType: "1", expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.FormValue("api_option") == "userdetails" {
+					_, _ = w.Write([]byte("<user><user_account_type>" + testCase.accountType + "</user_account_type></user>"))
+					return
+				}
+				_, _ = w.Write([]byte("https://pastebin.com/abcd1234"))
+			}))
+			defer server.Close()
+
+			client := newTestPastebinClient(t, server)
+			client.client.UserKey = testCase.userKey
+			_, err := client.CreatePaste(context.Background(), strings.Repeat("a", testCase.size), pasteOptions{})
+			var tooLargeErr *pasteTooLargeError
+			if errors.As(err, &tooLargeErr) != testCase.expectErr {
+				t.Errorf("expected paste too large error %t, got %v", testCase.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestPastebinClientRefreshesUserKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("api_user_key") != "fresh" {
+			_, _ = w.Write([]byte("Bad API request, invalid api_user_key"))
+			return
+		}
+		_, _ = w.Write([]byte("<user><user_name>karman</user_name></user>"))
+	}))
+	defer server.Close()
+
+	refreshes := 0
+	client := newTestPastebinClient(t, server)
+	client.refreshUserKey = func(_ context.Context) (string, error) {
+		refreshes++
+		return "fresh", nil
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		details, err := client.GetUserDetails(context.Background())
+		if err != nil || details.Name != "karman" {
+			t.Fatalf("expected the user details with a fresh user key, got %v (%v)", details, err)
+		}
+	}
+	if refreshes != 1 {
+		t.Errorf("expected the user key to be refreshed once, got %d refreshes", refreshes)
+	}
+}
+
+func TestPastebinClientRefreshUserKeyFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("Bad API request, invalid api_user_key"))
+	}))
+	defer server.Close()
+
+	client := newTestPastebinClient(t, server)
+	client.refreshUserKey = func(_ context.Context) (string, error) {
+		return "", errors.New("token expired")
+	}
+
+	_, err := client.GetUserDetails(context.Background())
+	if !errors.Is(err, errInvalidUserKey) || !strings.Contains(err.Error(), "token expired") {
+		t.Errorf("expected an invalid user key error with the refresh error, got %v", err)
+	}
+}
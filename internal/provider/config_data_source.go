@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &configDataSource{}
+	_ datasource.DataSourceWithConfigure = &configDataSource{}
+)
+
+// NewConfigDataSource is a helper function to simplify the provider implementation.
+func NewConfigDataSource() datasource.DataSource {
+	return &configDataSource{}
+}
+
+// configDataSource is the data source implementation. It reports the
+// configuration that the provider resolved, without making any requests.
+type configDataSource struct {
+	providerData *pastebinProviderData
+}
+
+// configDataSourceModel maps the data source schema data. The keys are never
+// part of it, only whether they are configured.
+type configDataSourceModel struct {
+	Host              types.String `tfsdk:"host"`
+	UserKeyConfigured types.Bool   `tfsdk:"user_key_configured"`
+	DefaultFormat     types.String `tfsdk:"default_format"`
+	DefaultExpire     types.String `tfsdk:"default_expire"`
+	DefaultVisibility types.String `tfsdk:"default_visibility"`
+}
+
+// Metadata returns the data source type name.
+func (d *configDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config"
+}
+
+// Schema defines the schema for the data source.
+func (d *configDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			// The host includes the base path, if configured.
+			"host": schema.StringAttribute{
+				Computed: true,
+			},
+			"user_key_configured": schema.BoolAttribute{
+				Computed: true,
+			},
+			// The default format and expire are null if Pastebin decides them.
+			"default_format": schema.StringAttribute{
+				Computed: true,
+			},
+			"default_expire": schema.StringAttribute{
+				Computed: true,
+			},
+			"default_visibility": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured data to the data source.
+func (d *configDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Read refreshes the Terraform state with the resolved provider configuration.
+func (d *configDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	client := d.providerData.Client
+
+	// New pastes are unlisted, unless the provider configures otherwise
+	defaultVisibility := "unlisted"
+	if d.providerData.DefaultVisibility != "" {
+		defaultVisibility = d.providerData.DefaultVisibility
+	}
+
+	state := configDataSourceModel{
+		Host:              types.StringValue(strings.TrimSuffix(client.url("/").String(), "/")),
+		UserKeyConfigured: types.BoolValue(client.userKey() != ""),
+		DefaultFormat:     providerDefault(d.providerData.DefaultFormat),
+		DefaultExpire:     providerDefault(d.providerData.DefaultExpire),
+		DefaultVisibility: types.StringValue(defaultVisibility),
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
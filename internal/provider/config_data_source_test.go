@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-pastebin/internal/pastebintest"
+)
+
+func TestConfigDataSourceRead(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	server.DevKey = "secret-dev"
+	server.UserKey = "secret-user"
+
+	testCases := map[string]struct {
+		config   map[string]tftypes.Value
+		expected map[string]tftypes.Value
+	}{
+		"defaults": {
+			config: map[string]tftypes.Value{
+				"host":    tftypes.NewValue(tftypes.String, server.URL),
+				"dev_key": tftypes.NewValue(tftypes.String, server.DevKey),
+			},
+			expected: map[string]tftypes.Value{
+				"host":                tftypes.NewValue(tftypes.String, server.URL),
+				"user_key_configured": tftypes.NewValue(tftypes.Bool, false),
+				"default_format":      tftypes.NewValue(tftypes.String, nil),
+				"default_expire":      tftypes.NewValue(tftypes.String, nil),
+				"default_visibility":  tftypes.NewValue(tftypes.String, "unlisted"),
+			},
+		},
+		"configured": {
+			config: map[string]tftypes.Value{
+				"host":               tftypes.NewValue(tftypes.String, server.URL),
+				"base_path":          tftypes.NewValue(tftypes.String, "/pastebin/"),
+				"dev_key":            tftypes.NewValue(tftypes.String, server.DevKey),
+				"user_key":           tftypes.NewValue(tftypes.String, server.UserKey),
+				"default_format":     tftypes.NewValue(tftypes.String, "go"),
+				"default_expire":     tftypes.NewValue(tftypes.String, "1 week"),
+				"default_visibility": tftypes.NewValue(tftypes.String, "private"),
+			},
+			expected: map[string]tftypes.Value{
+				"host":                tftypes.NewValue(tftypes.String, server.URL+"/pastebin"),
+				"user_key_configured": tftypes.NewValue(tftypes.Bool, true),
+				"default_format":      tftypes.NewValue(tftypes.String, "go"),
+				"default_expire":      tftypes.NewValue(tftypes.String, "1W"),
+				"default_visibility":  tftypes.NewValue(tftypes.String, "private"),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes, diagnostics := testReadDataSource(t, testCase.config, "pastebin_config", map[string]tftypes.Value{})
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			for name, expected := range testCase.expected {
+				if !attributes[name].Equal(expected) {
+					t.Errorf("expected %s %v, got %v", name, expected, attributes[name])
+				}
+			}
+
+			// The keys are never exposed
+			for name, value := range attributes {
+				if strings.Contains(value.String(), "secret-") {
+					t.Errorf("expected %s not to contain a key, got %v", name, value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// docsUrl is the url of the provider documentation, of which the
+// troubleshooting section explains how to resolve each error class.
+const docsUrl = "https://registry.terraform.io/providers/simonkarman/pastebin/latest/docs"
+
+// errorClass is the class of an error returned by the PasteBin API, which
+// determines the summary and documentation of its diagnostic.
+type errorClass string
+
+const (
+	errClassAuth          errorClass = "auth"
+	errClassRateLimit     errorClass = "rate_limit"
+	errClassInvalidFormat errorClass = "invalid_format"
+	errClassCanceled      errorClass = "canceled"
+	errClassUnexpected    errorClass = "unexpected"
+)
+
+// errorClassSummaries are the diagnostic summaries of the error classes.
+var errorClassSummaries = map[errorClass]string{
+	errClassAuth:          "PasteBin API Authentication Error",
+	errClassRateLimit:     "PasteBin API Rate Limit Error",
+	errClassInvalidFormat: "PasteBin API Invalid Request Error",
+	errClassCanceled:      "PasteBin API Request Canceled",
+	errClassUnexpected:    "Unexpected PasteBin API Error",
+}
+
+// errorClassAnchors are the anchors of the troubleshooting sections of the
+// error classes in the provider documentation.
+var errorClassAnchors = map[errorClass]string{
+	errClassAuth:          "authentication-errors",
+	errClassRateLimit:     "rate-limit-errors",
+	errClassInvalidFormat: "invalid-request-errors",
+	errClassCanceled:      "canceled-requests",
+	errClassUnexpected:    "troubleshooting",
+}
+
+// classifyError returns the class of an error returned by the client.
+func classifyError(err error) errorClass {
+	var statusErr *statusError
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return errClassCanceled
+	case errors.Is(err, errInvalidDevKey), errors.Is(err, errInvalidUserKey), errors.Is(err, errScrapingAccessDenied):
+		return errClassAuth
+	case errors.Is(err, errIPBlocked), errors.Is(err, errPasteLimitReached):
+		return errClassRateLimit
+	case errors.Is(err, errInvalidParameter), errors.Is(err, errPasteSizeExceeded):
+		return errClassInvalidFormat
+	case errors.As(err, &statusErr):
+		switch statusErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return errClassAuth
+		case http.StatusTooManyRequests:
+			return errClassRateLimit
+		}
+	}
+	return errClassUnexpected
+}
+
+// buildDiagnostic returns the error diagnostic of an error returned by the
+// client, which consists of the summary of its class, the error itself with
+// a hint to resolve it, and a link to the documentation of its class. The
+// error should describe the operation that failed.
+func buildDiagnostic(class errorClass, err error) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		errorClassSummaries[class],
+		"PasteBin Client Error: "+err.Error()+apiErrorHint(err)+"\n\n"+
+			"See "+docsUrl+"#"+errorClassAnchors[class]+" for more information.",
+	)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected errorClass
+	}{
+		"invalid dev key":   {err: newApiError("Bad API request, invalid api_dev_key"), expected: errClassAuth},
+		"inactive account":  {err: newApiError("Bad API request, account not active"), expected: errClassAuth},
+		"forbidden":         {err: &statusError{StatusCode: http.StatusForbidden}, expected: errClassAuth},
+		"scraping denied":   {err: fmt.Errorf("%w: YOUR IP: 127.0.0.1 DOES NOT HAVE ACCESS", errScrapingAccessDenied), expected: errClassAuth},
+		"ip blocked":        {err: newApiError("Bad API request, IP blocked"), expected: errClassRateLimit},
+		"paste limit":       {err: newApiError("Bad API request, maximum number of 25 unlisted pastes for your free account"), expected: errClassRateLimit},
+		"too many requests": {err: &statusError{StatusCode: http.StatusTooManyRequests}, expected: errClassRateLimit},
+		"invalid format":    {err: newApiError("Bad API request, invalid api_paste_format"), expected: errClassInvalidFormat},
+		"wrapped":           {err: fmt.Errorf("could not create paste: %w", newApiError("Bad API request, invalid api_expire_date")), expected: errClassInvalidFormat},
+		"server error":      {err: &statusError{StatusCode: http.StatusInternalServerError}, expected: errClassUnexpected},
+		"network error":     {err: errors.New("connection refused"), expected: errClassUnexpected},
+		"canceled":          {err: &url.Error{Op: "Post", URL: "https://pastebin.com", Err: context.Canceled}, expected: errClassCanceled},
+		"timed out":         {err: fmt.Errorf("could not delete paste: %w", context.DeadlineExceeded), expected: errClassCanceled},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if class := classifyError(testCase.err); class != testCase.expected {
+				t.Errorf("expected class %q, got %q", testCase.expected, class)
+			}
+		})
+	}
+}
+
+func TestBuildDiagnostic(t *testing.T) {
+	err := fmt.Errorf("could not create paste: %w", newApiError("Bad API request, invalid api_dev_key"))
+	diagnostic := buildDiagnostic(classifyError(err), err)
+
+	if diagnostic.Summary() != "PasteBin API Authentication Error" {
+		t.Errorf("unexpected summary %q", diagnostic.Summary())
+	}
+	for _, expected := range []string{"could not create paste: Bad API request, invalid api_dev_key", "PASTEBIN_DEV_KEY", docsUrl + "#authentication-errors"} {
+		if !strings.Contains(diagnostic.Detail(), expected) {
+			t.Errorf("expected the detail to contain %q, got %q", expected, diagnostic.Detail())
+		}
+	}
+}
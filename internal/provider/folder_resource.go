@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &folderResource{}
+	_ resource.ResourceWithConfigure = &folderResource{}
+)
+
+// NewFolderResource is a helper function to simplify the provider implementation.
+func NewFolderResource() resource.Resource {
+	return &folderResource{}
+}
+
+// folderResource is the resource implementation. Pastebin has no api to
+// manage folders, so the resource groups the pastes of an existing folder.
+type folderResource struct {
+	client *pastebinClient
+}
+
+// folderResourceModel maps the resource schema data.
+type folderResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	FolderKey types.String `tfsdk:"folder_key"`
+	PasteKeys types.Set    `tfsdk:"paste_keys"`
+	Cascade   types.Bool   `tfsdk:"cascade"`
+}
+
+// folderDescription is the description of the folder resource.
+const folderDescription = "Groups the pastes of a Pastebin folder. Folders are only available for Pastebin PRO accounts, " +
+	"and can only be created on the Pastebin website, so the folder must already exist. " +
+	"Pastebin does not report the folder of a paste, so the pastes of the folder are the pastes that are listed in paste_keys. " +
+	"Their membership of the folder cannot be checked through the api, so refreshing only warns about pastes that no longer exist."
+
+// Metadata returns the resource type name.
+func (r *folderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_folder"
+}
+
+// Schema defines the schema for the resource.
+func (r *folderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         folderDescription,
+		MarkdownDescription: folderDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"folder_key": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringNotBlank(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			// Kept as configured, as Pastebin cannot report the pastes of a folder.
+			"paste_keys": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			// Cascading deletes the pastes of the folder when the folder is destroyed.
+			"cascade": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *folderResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+// Create checks that the account supports folders and sets the initial
+// Terraform state.
+func (r *folderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan folderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Folders belong to an authenticated PRO user
+	if r.client.client.UserKey == "" {
+		resp.Diagnostics.Append(missingUserKeyError("Grouping the pastes of a folder"))
+		return
+	}
+	accountType, err := r.client.AccountType(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to read the account type to check the support for folders", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else if accountType != "pro" {
+		resp.Diagnostics.AddError(
+			"Pastebin Folder Requires PRO Account",
+			"The folder "+plan.FolderKey.ValueString()+" cannot be used, as folders are only available for Pastebin PRO accounts "+
+				"and the provider is configured for a "+accountType+" account.",
+		)
+		return
+	}
+
+	// Map response to schema
+	plan.ID = plan.FolderKey
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the pastes of the folder that
+// still exist.
+func (r *folderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state folderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.PasteKeys.IsNull() || r.client.client.UserKey == "" {
+		return
+	}
+
+	var pasteKeys []string
+	resp.Diagnostics.Append(state.PasteKeys.ElementsAs(ctx, &pasteKeys, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The list api has no paging, so a paste can only be confirmed to be gone
+	// if the user has fewer pastes than the maximum number of results
+	pastes, err := r.client.ListPastes(ctx, maxListResults)
+	if err != nil {
+		resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not list the pastes of folder %s: %w", state.FolderKey.ValueString(), err)))
+		return
+	}
+	if len(pastes) >= maxListResults {
+		return
+	}
+	listed := map[string]bool{}
+	for _, paste := range pastes {
+		listed[paste.Key] = true
+	}
+	missing := []string{}
+	for _, pasteKey := range pasteKeys {
+		if !listed[pasteKey] {
+			missing = append(missing, pasteKey)
+		}
+	}
+
+	// The configured pastes are kept, so a missing paste does not cause a
+	// diff on every plan
+	if len(missing) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("paste_keys"),
+			"Missing Pastebin Folder Pastes",
+			"The pastes "+strings.Join(missing, ", ")+" of folder "+state.FolderKey.ValueString()+" no longer exist, for example because they expired. "+
+				"Remove them from paste_keys. Pastebin does not report the folder of a paste, so only the existence of the pastes is checked.",
+		)
+	}
+}
+
+// Update updates the pastes of the folder in the Terraform state.
+func (r *folderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan folderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the pastes of the folder if cascading, and removes the
+// Terraform state on success. The folder itself remains on Pastebin.
+func (r *folderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state folderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !state.Cascade.ValueBool() || state.PasteKeys.IsNull() {
+		return
+	}
+
+	var pasteKeys []string
+	resp.Diagnostics.Append(state.PasteKeys.ElementsAs(ctx, &pasteKeys, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A paste that is already deleted, such as by its own resource, counts
+	// as deleted
+	for _, pasteKey := range pasteKeys {
+		err := r.client.DeletePaste(ctx, pasteKey)
+		if err != nil && !errors.Is(err, errPasteNotFound) {
+			resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not delete paste %s of folder %s: %w", pasteKey, state.FolderKey.ValueString(), err)))
+			return
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-pastebin/internal/pastebintest"
+)
+
+func testFolderPasteKeys(keys ...string) tftypes.Value {
+	values := make([]tftypes.Value, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, tftypes.NewValue(tftypes.String, key))
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+}
+
+func TestFolderResourceCreate(t *testing.T) {
+	testCases := map[string]struct {
+		accountType string
+		expectErr   bool
+	}{
+		"pro":    {accountType: "1"},
+		"normal": {accountType: "0", expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			server.AccountType = testCase.accountType
+
+			state, diagnostics := testApplyResourceCreate(t, testFakeProviderConfig(server), "pastebin_folder", map[string]tftypes.Value{
+				"folder_key": tftypes.NewValue(tftypes.String, "snippets"),
+			})
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Fatalf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+			if testCase.expectErr {
+				if diagnostics[0].Summary != "Pastebin Folder Requires PRO Account" {
+					t.Errorf("expected a PRO account error, got %v", diagnostics)
+				}
+				return
+			}
+			if !state["id"].Equal(tftypes.NewValue(tftypes.String, "snippets")) {
+				t.Errorf("expected id snippets, got %v", state["id"])
+			}
+		})
+	}
+}
+
+func TestFolderResourceReadWarnsAboutDeletedPastes(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	server.AccountType = "1"
+	kept := server.AddPaste(pastebintest.Paste{Content: "Kept.", Owner: server.UserKey})
+
+	state, diagnostics := testReadResource(t, testFakeProviderConfig(server), "pastebin_folder", map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "snippets"),
+		"folder_key": tftypes.NewValue(tftypes.String, "snippets"),
+		"paste_keys": testFolderPasteKeys(kept, "gone1234"),
+		"cascade":    tftypes.NewValue(tftypes.Bool, false),
+	})
+	if testHasError(diagnostics) {
+		t.Fatalf("unexpected error %v", diagnostics)
+	}
+	if expected := testFolderPasteKeys(kept, "gone1234"); !state["paste_keys"].Equal(expected) {
+		t.Errorf("expected paste keys %v, got %v", expected, state["paste_keys"])
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Severity != tfprotov6.DiagnosticSeverityWarning || !strings.Contains(diagnostics[0].Detail, "gone1234") {
+		t.Errorf("expected a warning about the missing paste, got %v", diagnostics)
+	}
+}
+
+func TestFolderResourceDestroy(t *testing.T) {
+	testCases := map[string]struct {
+		cascade bool
+	}{
+		"cascade":    {cascade: true},
+		"no cascade": {cascade: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			server.AccountType = "1"
+			first := server.AddPaste(pastebintest.Paste{Content: "First.", Owner: server.UserKey})
+			second := server.AddPaste(pastebintest.Paste{Content: "Second.", Owner: server.UserKey})
+
+			diagnostics := testDestroyResource(t, testFakeProviderConfig(server), "pastebin_folder", map[string]tftypes.Value{
+				"id":         tftypes.NewValue(tftypes.String, "snippets"),
+				"folder_key": tftypes.NewValue(tftypes.String, "snippets"),
+				"paste_keys": testFolderPasteKeys(first, second, "gone1234"),
+				"cascade":    tftypes.NewValue(tftypes.Bool, testCase.cascade),
+			})
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			for _, key := range []string{first, second} {
+				if _, ok := server.Paste(key); ok == testCase.cascade {
+					t.Errorf("expected paste %s to exist %t, got %t", key, !testCase.cascade, ok)
+				}
+			}
+		})
+	}
+}
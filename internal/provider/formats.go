@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// pasteFormats are the syntax highlighting formats supported by Pastebin as
+// api_paste_format values, see https://pastebin.com/doc_api#5.
+var pasteFormats = []string{
+	"4cs", "6502acme", "6502kickass", "6502tasm", "68000devpac", "abap", "actionscript",
+	"actionscript3", "ada", "aimms", "algol68", "apache", "applescript", "apt_sources", "arduino",
+	"arm", "asm", "asp", "asymptote", "autoconf", "autohotkey", "autoit", "avisynth", "awk", "b3d",
+	"bascomavr", "bash", "basic4gl", "bf", "bibtex", "blitzbasic", "bmx", "bnf", "boo", "c",
+	"c_loadrunner", "c_mac", "c_winapi", "caddcl", "cadlisp", "ceylon", "cfdg", "cfm", "chaiscript",
+	"chapel", "cil", "clojure", "cmake", "cobol", "coffeescript", "cpp", "cpp-qt", "cpp-winapi",
+	"csharp", "css", "cuesheet", "d", "dart", "dcl", "dcpu16", "dcs", "delphi", "diff", "div", "dos",
+	"dot", "e", "ecmascript", "eiffel", "email", "epc", "erlang", "euphoria", "ezt", "f1", "falcon",
+	"filemaker", "fo", "fortran", "freebasic", "freeswitch", "fsharp", "gambas", "gdb", "gdscript",
+	"genero", "genie", "gettext", "glsl", "gml", "gnuplot", "go", "godot-glsl", "groovy", "gwbasic",
+	"haskell", "haxe", "hicest", "hq9plus", "html4strict", "html5", "icon", "idl", "ini", "inno",
+	"intercal", "io", "ispfpanel", "j", "java", "java5", "javascript", "jcl", "jquery", "json",
+	"julia", "kixtart", "klonec", "klonecpp", "kotlin", "ksp", "latex", "lb", "ldif", "lisp", "llvm",
+	"locobasic", "logtalk", "lolcode", "lotusformulas", "lotusscript", "lscript", "lsl2", "lua",
+	"m68k", "magiksf", "make", "mapbasic", "markdown", "matlab", "mercury", "metapost", "mirc",
+	"mk-61", "mmix", "modula2", "modula3", "mpasm", "mxml", "mysql", "nagios", "netrexx", "newlisp",
+	"nginx", "nim", "nsis", "oberon2", "objc", "objeck", "ocaml", "ocaml-brief", "octave", "oobas",
+	"oorexx", "oracle11", "oracle8", "oxygene", "oz", "parasail", "parigp", "pascal", "pawn", "pcre",
+	"per", "perl", "perl6", "pf", "phix", "php", "php-brief", "pic16", "pike", "pixelbender", "pli",
+	"plsql", "postgresql", "postscript", "povray", "powerbuilder", "powershell", "proftpd",
+	"progress", "prolog", "properties", "providex", "puppet", "purebasic", "pycon", "pys60", "python",
+	"q", "qbasic", "qml", "racket", "rails", "rbs", "rebol", "reg", "rexx", "robots", "roff",
+	"rpmspec", "rsplus", "ruby", "rust", "sas", "scala", "scheme", "scilab", "scl", "sclang",
+	"sdlbasic", "smalltalk", "smarty", "spark", "sparql", "sqf", "sql", "sshconfig", "standardml",
+	"stonescript", "swift", "systemverilog", "tcl", "teraterm", "texgraph", "text", "thinbasic",
+	"tsql", "typescript", "typoscript", "unicon", "upc", "urbi", "uscript", "vala", "vb", "vbnet",
+	"vbscript", "vedit", "verilog", "vhdl", "vim", "visualfoxpro", "visualprolog", "whitespace",
+	"whois", "winbatch", "xbasic", "xml", "xojo", "xorg_conf", "xpp", "yaml", "yara", "z80",
+	"zxbasic",
+}
+
+// pasteFormatExtensions maps file extensions to the pasteFormats that are
+// used for files with that extension.
+var pasteFormatExtensions = map[string]string{
+	".asm":        "asm",
+	".awk":        "awk",
+	".bat":        "dos",
+	".c":          "c",
+	".cc":         "cpp",
+	".clj":        "clojure",
+	".cmake":      "cmake",
+	".coffee":     "coffeescript",
+	".cpp":        "cpp",
+	".cs":         "csharp",
+	".css":        "css",
+	".d":          "d",
+	".dart":       "dart",
+	".diff":       "diff",
+	".erl":        "erlang",
+	".f90":        "fortran",
+	".fs":         "fsharp",
+	".go":         "go",
+	".groovy":     "groovy",
+	".h":          "c",
+	".hpp":        "cpp",
+	".hs":         "haskell",
+	".htm":        "html5",
+	".html":       "html5",
+	".ini":        "ini",
+	".java":       "java",
+	".jl":         "julia",
+	".js":         "javascript",
+	".json":       "json",
+	".kt":         "kotlin",
+	".latex":      "latex",
+	".lisp":       "lisp",
+	".lua":        "lua",
+	".m":          "objc",
+	".md":         "markdown",
+	".mk":         "make",
+	".ml":         "ocaml",
+	".nim":        "nim",
+	".pas":        "pascal",
+	".patch":      "diff",
+	".php":        "php",
+	".pl":         "perl",
+	".properties": "properties",
+	".ps1":        "powershell",
+	".py":         "python",
+	".r":          "rsplus",
+	".rb":         "ruby",
+	".rkt":        "racket",
+	".rs":         "rust",
+	".scala":      "scala",
+	".scm":        "scheme",
+	".sh":         "bash",
+	".sql":        "sql",
+	".swift":      "swift",
+	".tcl":        "tcl",
+	".tex":        "latex",
+	".ts":         "typescript",
+	".txt":        "text",
+	".vb":         "vbnet",
+	".vbs":        "vbscript",
+	".vhd":        "vhdl",
+	".vim":        "vim",
+	".xml":        "xml",
+	".yaml":       "yaml",
+	".yml":        "yaml",
+}
+
+// formatFromExtension returns the paste format of a file based on its
+// extension, or text if the extension is unknown.
+func formatFromExtension(name string) string {
+	if format, ok := pasteFormatExtensions[strings.ToLower(filepath.Ext(name))]; ok {
+		return format
+	}
+	return "text"
+}
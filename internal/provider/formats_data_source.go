@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &formatsDataSource{}
+)
+
+// NewFormatsDataSource is a helper function to simplify the provider implementation.
+func NewFormatsDataSource() datasource.DataSource {
+	return &formatsDataSource{}
+}
+
+// formatsDataSource is the data source implementation. The formats are known
+// to the provider, so it does not use the client.
+type formatsDataSource struct{}
+
+// formatsDataSourceModel maps the data source schema data.
+type formatsDataSourceModel struct {
+	Filter  types.String   `tfsdk:"filter"`
+	Formats []types.String `tfsdk:"formats"`
+}
+
+// Metadata returns the data source type name.
+func (d *formatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_formats"
+}
+
+// Schema defines the schema for the data source.
+func (d *formatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			// The filter only returns the formats that contain it, ignoring case.
+			"filter": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringNotBlank(),
+				},
+			},
+			"formats": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *formatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state formatsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Map the formats that match the filter to the model, which are none if
+	// nothing matches
+	filter := strings.ToLower(state.Filter.ValueString())
+	state.Formats = []types.String{}
+	for _, format := range pasteFormats {
+		if strings.Contains(format, filter) {
+			state.Formats = append(state.Formats, types.StringValue(format))
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
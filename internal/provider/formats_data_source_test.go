@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFormatsDataSourceRead(t *testing.T) {
+	providerConfig := map[string]tftypes.Value{
+		"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+	}
+	testCases := map[string]struct {
+		filter   tftypes.Value
+		expected []string
+	}{
+		"all":         {filter: tftypes.NewValue(tftypes.String, nil), expected: pasteFormats},
+		"filter":      {filter: tftypes.NewValue(tftypes.String, "script3"), expected: []string{"actionscript3"}},
+		"ignore case": {filter: tftypes.NewValue(tftypes.String, "YAM"), expected: []string{"yaml"}},
+		"no match":    {filter: tftypes.NewValue(tftypes.String, "cobra"), expected: []string{}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes, diagnostics := testReadDataSource(t, providerConfig, "pastebin_formats", map[string]tftypes.Value{
+				"filter": testCase.filter,
+			})
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			var formats []tftypes.Value
+			if err := attributes["formats"].As(&formats); err != nil {
+				t.Fatal(err)
+			}
+			if len(formats) != len(testCase.expected) {
+				t.Fatalf("expected %d formats, got %d", len(testCase.expected), len(formats))
+			}
+			for i, format := range formats {
+				var value string
+				if err := format.As(&value); err != nil {
+					t.Fatal(err)
+				}
+				if value != testCase.expected[i] {
+					t.Errorf("expected format %d to be %q, got %q", i, testCase.expected[i], value)
+				}
+			}
+		})
+	}
+}
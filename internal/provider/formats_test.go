@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFormatFromExtension(t *testing.T) {
+	testCases := map[string]string{
+		"main.go":            "go",
+		"scripts/build.py":   "python",
+		"config.yaml":        "yaml",
+		"config.yml":         "yaml",
+		"deploy.sh":          "bash",
+		"README.MD":          "markdown",
+		"archive.tar.gz":     "text",
+		"Makefile":           "text",
+		"notes.unknownext":   "text",
+		"/tmp/dir.d/example": "text",
+	}
+
+	for name, expected := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if format := formatFromExtension(name); format != expected {
+				t.Errorf("expected format %q, got %q", expected, format)
+			}
+		})
+	}
+}
+
+func TestPasteFormatExtensionsAreSupported(t *testing.T) {
+	for extension, format := range pasteFormatExtensions {
+		if !slices.Contains(pasteFormats, format) {
+			t.Errorf("expected the format %q of extension %s to be a supported format", format, extension)
+		}
+	}
+}
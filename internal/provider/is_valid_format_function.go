@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &isValidFormatFunction{}
+)
+
+// NewIsValidFormatFunction is a helper function to simplify the provider implementation.
+func NewIsValidFormatFunction() function.Function {
+	return &isValidFormatFunction{}
+}
+
+// isValidFormatFunction is the function implementation.
+type isValidFormatFunction struct{}
+
+// Metadata returns the function name.
+func (f *isValidFormatFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_format"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *isValidFormatFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns whether a format is a supported Pastebin syntax highlighting format",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name: "format",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run returns whether the format is one of the supported paste formats.
+func (f *isValidFormatFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var format string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &format))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, isPasteFormat(format)))
+}
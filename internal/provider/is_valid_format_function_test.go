@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestIsValidFormatFunction(t *testing.T) {
+	testCases := map[string]bool{
+		"yaml":   true,
+		"text":   true,
+		"yml":    false,
+		"Python": false,
+		"":       false,
+	}
+
+	for format, expected := range testCases {
+		t.Run(format, func(t *testing.T) {
+			result, funcErr := testCallFunction(t, "is_valid_format", tftypes.NewValue(tftypes.String, format))
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+			if !result.Equal(tftypes.NewValue(tftypes.Bool, expected)) {
+				t.Errorf("expected %t, got %s", expected, result)
+			}
+		})
+	}
+}
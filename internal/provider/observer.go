@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RequestObserver observes every attempt of a request to the PasteBin API,
+// such as to collect latency and error metrics when embedding the provider.
+// The callbacks are called from the goroutine that sends the request and must
+// not modify the request or response.
+type RequestObserver interface {
+	// OnRequest is called before the request is sent.
+	OnRequest(req *http.Request)
+	// OnResponse is called when the response is received, with the duration
+	// since the request was sent.
+	OnResponse(req *http.Request, resp *http.Response, duration time.Duration)
+	// OnError is called when the request failed without a response, with the
+	// duration since the request was sent.
+	OnError(req *http.Request, err error, duration time.Duration)
+}
+
+// noopObserver is a RequestObserver that does nothing.
+type noopObserver struct{}
+
+// OnRequest does nothing.
+func (noopObserver) OnRequest(*http.Request) {}
+
+// OnResponse does nothing.
+func (noopObserver) OnResponse(*http.Request, *http.Response, time.Duration) {}
+
+// OnError does nothing.
+func (noopObserver) OnError(*http.Request, error, time.Duration) {}
+
+// timingObserver is a RequestObserver that logs the latency of every request.
+type timingObserver struct{}
+
+// OnRequest does nothing, as the latency is known once the request is done.
+func (timingObserver) OnRequest(*http.Request) {}
+
+// OnResponse logs the latency of the request and its status.
+func (timingObserver) OnResponse(req *http.Request, resp *http.Response, duration time.Duration) {
+	tflog.SubsystemInfo(tflog.NewSubsystem(req.Context(), apiLogSubsystem), apiLogSubsystem, "PasteBin API request timing", map[string]interface{}{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"status":      resp.StatusCode,
+		"duration_ms": duration.Milliseconds(),
+	})
+}
+
+// OnError logs the latency of the failed request and its error.
+func (timingObserver) OnError(req *http.Request, err error, duration time.Duration) {
+	tflog.SubsystemInfo(tflog.NewSubsystem(req.Context(), apiLogSubsystem), apiLogSubsystem, "PasteBin API request timing", map[string]interface{}{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"error":       err.Error(),
+		"duration_ms": duration.Milliseconds(),
+	})
+}
+
+// observerTransport is a http.RoundTripper that reports every request to its
+// observers.
+type observerTransport struct {
+	base      http.RoundTripper
+	observers []RequestObserver
+}
+
+// newObserverTransport creates a new observerTransport that sends its
+// requests using the base transport.
+func newObserverTransport(base http.RoundTripper, observers ...RequestObserver) *observerTransport {
+	return &observerTransport{
+		base:      base,
+		observers: observers,
+	}
+}
+
+// RoundTrip executes the request and reports it to the observers.
+func (t *observerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, observer := range t.observers {
+		observer.OnRequest(req)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start)
+	for _, observer := range t.observers {
+		if err != nil {
+			observer.OnError(req, err, duration)
+		} else {
+			observer.OnResponse(req, resp, duration)
+		}
+	}
+	return resp, err
+}
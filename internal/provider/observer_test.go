@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// recordingObserver is a RequestObserver that records its callbacks.
+type recordingObserver struct {
+	calls []string
+}
+
+func (o *recordingObserver) OnRequest(*http.Request) {
+	o.calls = append(o.calls, "request")
+}
+
+func (o *recordingObserver) OnResponse(_ *http.Request, resp *http.Response, _ time.Duration) {
+	o.calls = append(o.calls, "response "+http.StatusText(resp.StatusCode))
+}
+
+func (o *recordingObserver) OnError(_ *http.Request, err error, _ time.Duration) {
+	o.calls = append(o.calls, "error")
+}
+
+// roundTripFunc is a http.RoundTripper that calls the function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestObserverTransport(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	observer := &recordingObserver{}
+	client := &http.Client{
+		Transport: newRetryTransport(newObserverTransport(server.Client().Transport, observer), 1, time.Millisecond, nil),
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	expected := []string{"request", "response Service Unavailable", "request", "response OK"}
+	if len(observer.calls) != len(expected) {
+		t.Fatalf("expected calls %v, got %v", expected, observer.calls)
+	}
+	for i := range expected {
+		if observer.calls[i] != expected[i] {
+			t.Errorf("expected calls %v, got %v", expected, observer.calls)
+			break
+		}
+	}
+}
+
+func TestObserverTransportError(t *testing.T) {
+	failing := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	observer := &recordingObserver{}
+	req, err := http.NewRequest(http.MethodGet, "https://pastebin.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newObserverTransport(failing, noopObserver{}, observer).RoundTrip(req); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(observer.calls) != 2 || observer.calls[1] != "error" {
+		t.Errorf("expected a request and an error, got %v", observer.calls)
+	}
+}
+
+func TestTimingObserver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/api/api_post.php", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: newObserverTransport(server.Client().Transport, timingObserver{})}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry["@level"] != "info" || entry["path"] != "/api/api_post.php" || entry["status"] != float64(http.StatusTeapot) {
+		t.Errorf("unexpected timing log entry %v", entry)
+	}
+	if _, ok := entry["duration_ms"]; !ok {
+		t.Errorf("expected the duration in the timing log entry, got %v", entry)
+	}
+}
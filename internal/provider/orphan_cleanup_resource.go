@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &orphanCleanupResource{}
+	_ resource.ResourceWithConfigure      = &orphanCleanupResource{}
+	_ resource.ResourceWithValidateConfig = &orphanCleanupResource{}
+)
+
+// NewOrphanCleanupResource is a helper function to simplify the provider implementation.
+func NewOrphanCleanupResource() resource.Resource {
+	return &orphanCleanupResource{}
+}
+
+// orphanCleanupResource is the resource implementation. It deletes the pastes
+// of the user that are not managed by Terraform.
+type orphanCleanupResource struct {
+	client *pastebinClient
+}
+
+// orphanCleanupResourceModel maps the resource schema data.
+type orphanCleanupResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	ManagedKeys     types.Set    `tfsdk:"managed_keys"`
+	DryRun          types.Bool   `tfsdk:"dry_run"`
+	ConfirmDeletion types.Bool   `tfsdk:"confirm_deletion"`
+	DeletedKeys     types.List   `tfsdk:"deleted_keys"`
+}
+
+// Metadata returns the resource type name.
+func (r *orphanCleanupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_paste_orphan_cleanup"
+}
+
+// Schema defines the schema for the resource.
+func (r *orphanCleanupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			// The pastes are only cleaned up on create, so any change runs the
+			// cleanup again.
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			// The keys of the pastes that are kept, such as the ids of the
+			// pastebin_paste resources.
+			"managed_keys": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			// A dry run only reports the keys of the unmanaged pastes, which is
+			// the default to avoid deleting pastes by accident.
+			"dry_run": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			// Deleting pastes requires an explicit confirmation in addition to
+			// disabling the dry run.
+			"confirm_deletion": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"deleted_keys": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// ValidateConfig requires the confirmation of the deletion of pastes.
+func (r *orphanCleanupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config orphanCleanupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.DryRun.IsNull() && !config.DryRun.IsUnknown() && !config.DryRun.ValueBool() &&
+		!config.ConfirmDeletion.IsUnknown() && !config.ConfirmDeletion.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("confirm_deletion"),
+			"Pastebin Orphan Cleanup Not Confirmed",
+			"Disabling the dry run deletes every paste of the user that is not in managed_keys. "+
+				"Set confirm_deletion to true to confirm the deletion, or enable the dry run to only report the pastes that would be deleted.",
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *orphanCleanupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+// Create deletes the pastes of the user that are not managed and sets the
+// initial Terraform state.
+func (r *orphanCleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan orphanCleanupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Listing and deleting pastes requires an authenticated user, which
+	// scopes the cleanup to the pastes of that user
+	if r.client.client.UserKey == "" {
+		resp.Diagnostics.Append(missingUserKeyError("Cleaning up the orphaned pastes of a user"))
+		return
+	}
+
+	var managedKeys []string
+	resp.Diagnostics.Append(plan.ManagedKeys.ElementsAs(ctx, &managedKeys, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	managed := map[string]bool{}
+	for _, managedKey := range managedKeys {
+		managed[managedKey] = true
+	}
+
+	// Without managed keys every paste is an orphan, which is more likely a
+	// mistake in the configuration than the intent
+	dryRun := plan.DryRun.ValueBool() || !plan.ConfirmDeletion.ValueBool()
+	if len(managed) == 0 && !dryRun {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("managed_keys"),
+			"Empty Pastebin Managed Paste Keys",
+			"The orphan cleanup would delete every paste of the user, as managed_keys is empty. "+
+				"Use the pastebin_cleanup resource to delete pastes by title or age instead.",
+		)
+		return
+	}
+
+	// The list api has no paging, so the maximum number of results is all
+	// that can be cleaned up in a single run
+	pastes, err := r.client.ListPastes(ctx, maxListResults)
+	if err != nil {
+		resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not list the pastes of the user: %w", err)))
+		return
+	}
+	if len(pastes) >= maxListResults {
+		resp.Diagnostics.AddWarning(
+			"Incomplete Pastebin Paste Cleanup",
+			fmt.Sprintf("The user has at least %d pastes, which is the maximum number of pastes the PasteBin API lists. "+
+				"Pastes beyond this limit are not cleaned up until a next run.", maxListResults),
+		)
+	}
+
+	// Delete the pastes that are not managed, of which a paste that is
+	// already deleted counts as deleted, so a failed cleanup can simply run
+	// again
+	deletedKeys := []string{}
+	for _, paste := range pastes {
+		if managed[paste.Key] {
+			continue
+		}
+		if !dryRun {
+			err := r.client.DeletePaste(ctx, paste.Key)
+			if err != nil && !errors.Is(err, errPasteNotFound) {
+				resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not delete paste %s after deleting %d pastes: %w", paste.Key, len(deletedKeys), err)))
+				return
+			}
+		}
+		deletedKeys = append(deletedKeys, paste.Key)
+	}
+
+	// Map response to schema
+	plan.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	plan.DeletedKeys, diags = types.ListValueFrom(ctx, types.StringType, deletedKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the Terraform state, as the cleanup has nothing to refresh.
+func (r *orphanCleanupResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update is never called, as every change runs the cleanup again.
+func (r *orphanCleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan orphanCleanupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform state, which leaves the deleted pastes deleted.
+func (r *orphanCleanupResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
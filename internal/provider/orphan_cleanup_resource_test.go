@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-pastebin/internal/pastebintest"
+)
+
+// testManagedKeys returns a managed_keys set with the given keys.
+func testManagedKeys(keys ...string) tftypes.Value {
+	values := []tftypes.Value{}
+	for _, key := range keys {
+		values = append(values, tftypes.NewValue(tftypes.String, key))
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+}
+
+func TestOrphanCleanupResourceValidateConfig(t *testing.T) {
+	testCases := map[string]struct {
+		config    map[string]tftypes.Value
+		expectErr bool
+	}{
+		"dry run by default": {
+			config: map[string]tftypes.Value{
+				"managed_keys": testManagedKeys("abcd1234"),
+			},
+		},
+		"confirmed": {
+			config: map[string]tftypes.Value{
+				"managed_keys":     testManagedKeys("abcd1234"),
+				"dry_run":          tftypes.NewValue(tftypes.Bool, false),
+				"confirm_deletion": tftypes.NewValue(tftypes.Bool, true),
+			},
+		},
+		"not confirmed": {
+			config: map[string]tftypes.Value{
+				"managed_keys": testManagedKeys("abcd1234"),
+				"dry_run":      tftypes.NewValue(tftypes.Bool, false),
+			},
+			expectErr: true,
+		},
+		"no managed keys": {
+			config:    map[string]tftypes.Value{},
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diagnostics := testValidateResourceConfig(t, "pastebin_paste_orphan_cleanup", testCase.config)
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+		})
+	}
+}
+
+func TestOrphanCleanupResourceCreate(t *testing.T) {
+	testCases := map[string]struct {
+		config    map[string]tftypes.Value
+		expectErr bool
+		deleted   bool
+	}{
+		"dry run": {
+			config: map[string]tftypes.Value{
+				"managed_keys": testManagedKeys("managed"),
+			},
+		},
+		"confirmed": {
+			config: map[string]tftypes.Value{
+				"managed_keys":     testManagedKeys("managed"),
+				"dry_run":          tftypes.NewValue(tftypes.Bool, false),
+				"confirm_deletion": tftypes.NewValue(tftypes.Bool, true),
+			},
+			deleted: true,
+		},
+		"empty managed keys": {
+			config: map[string]tftypes.Value{
+				"managed_keys":     testManagedKeys(),
+				"dry_run":          tftypes.NewValue(tftypes.Bool, false),
+				"confirm_deletion": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			server.AddPaste(pastebintest.Paste{Key: "managed", Content: "Managed.", Owner: server.UserKey})
+			server.AddPaste(pastebintest.Paste{Key: "orphan", Content: "Orphan.", Owner: server.UserKey})
+			server.AddPaste(pastebintest.Paste{Key: "other", Content: "Of another user.", Owner: "other"})
+
+			state, diagnostics := testApplyResourceCreate(t, testFakeProviderConfig(server), "pastebin_paste_orphan_cleanup", testCase.config)
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Fatalf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+			if !testCase.expectErr && !state["deleted_keys"].Equal(tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "orphan"),
+			})) {
+				t.Errorf("expected the orphan to be reported, got %v", state["deleted_keys"])
+			}
+			if _, exists := server.Paste("orphan"); exists == testCase.deleted {
+				t.Errorf("expected deleted %t for the orphan", testCase.deleted)
+			}
+			for _, key := range []string{"managed", "other"} {
+				if _, exists := server.Paste(key); !exists {
+					t.Errorf("expected paste %s to be kept", key)
+				}
+			}
+		})
+	}
+}
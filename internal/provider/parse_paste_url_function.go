@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &parsePasteUrlFunction{}
+)
+
+// NewParsePasteUrlFunction is a helper function to simplify the provider implementation.
+func NewParsePasteUrlFunction() function.Function {
+	return &parsePasteUrlFunction{}
+}
+
+// parsePasteUrlFunction is the function implementation.
+type parsePasteUrlFunction struct{}
+
+// parsedPasteUrl maps the object that the function returns.
+type parsedPasteUrl struct {
+	Host  string `tfsdk:"host"`
+	Key   string `tfsdk:"key"`
+	IsRaw bool   `tfsdk:"is_raw"`
+}
+
+// Metadata returns the function name.
+func (f *parsePasteUrlFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_paste_url"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parsePasteUrlFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses the url of a paste into its host, key and whether it is a raw url",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name: "url",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"host":   types.StringType,
+				"key":    types.StringType,
+				"is_raw": types.BoolType,
+			},
+		},
+	}
+}
+
+// Run returns the components of the paste url.
+func (f *parsePasteUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	pasteUrl, err := url.Parse(value)
+	if err != nil || pasteUrl.Scheme == "" || pasteUrl.Host == "" {
+		resp.Error = function.NewArgumentFuncError(0, "The url must be a paste url such as 'https://pastebin.com/abcd1234', got: "+value)
+		return
+	}
+	pasteKey, err := parsePasteKey(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "The url must be a paste url such as 'https://pastebin.com/abcd1234', but the "+err.Error()+".")
+		return
+	}
+
+	// A raw url has a raw segment right before the key
+	segments := strings.Split(strings.Trim(pasteUrl.Path, "/"), "/")
+	isRaw := len(segments) >= 2 && segments[len(segments)-2] == "raw"
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, parsedPasteUrl{
+		Host:  pasteUrl.Host,
+		Key:   pasteKey,
+		IsRaw: isRaw,
+	}))
+}
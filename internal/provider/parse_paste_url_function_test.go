@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParsePasteUrlFunction(t *testing.T) {
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"host":   tftypes.String,
+		"key":    tftypes.String,
+		"is_raw": tftypes.Bool,
+	}}
+
+	testCases := map[string]struct {
+		url       string
+		host      string
+		key       string
+		isRaw     bool
+		expectErr bool
+	}{
+		"paste url":             {url: "https://pastebin.com/abcd1234", host: "pastebin.com", key: "abcd1234"},
+		"raw url":               {url: "https://pastebin.com/raw/abcd1234", host: "pastebin.com", key: "abcd1234", isRaw: true},
+		"trailing slash":        {url: "https://pastebin.com/raw/abcd1234/", host: "pastebin.com", key: "abcd1234", isRaw: true},
+		"self-hosted with port": {url: "http://localhost:8080/pastebin/abcd1234", host: "localhost:8080", key: "abcd1234"},
+		"self-hosted raw url":   {url: "https://paste.example.com/pastebin/raw/abcd1234", host: "paste.example.com", key: "abcd1234", isRaw: true},
+		"paste key":             {url: "abcd1234", expectErr: true},
+		"without scheme":        {url: "pastebin.com/abcd1234", expectErr: true},
+		"without key":           {url: "https://pastebin.com/", expectErr: true},
+		"invalid key":           {url: "https://pastebin.com/raw/abc-1234", expectErr: true},
+		"empty":                 {url: "", expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := testCallFunction(t, "parse_paste_url", tftypes.NewValue(tftypes.String, testCase.url))
+			if (funcErr != nil) != testCase.expectErr {
+				t.Fatalf("expected error %t, got %v", testCase.expectErr, funcErr)
+			}
+			if testCase.expectErr {
+				return
+			}
+			expected := tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"host":   tftypes.NewValue(tftypes.String, testCase.host),
+				"key":    tftypes.NewValue(tftypes.String, testCase.key),
+				"is_raw": tftypes.NewValue(tftypes.Bool, testCase.isRaw),
+			})
+			if !result.Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &pasteCountDataSource{}
+	_ datasource.DataSourceWithConfigure        = &pasteCountDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &pasteCountDataSource{}
+)
+
+// NewPasteCountDataSource is a helper function to simplify the provider implementation.
+func NewPasteCountDataSource() datasource.DataSource {
+	return &pasteCountDataSource{}
+}
+
+// pasteCountDataSource is the data source implementation.
+type pasteCountDataSource struct {
+	client *pastebinClient
+}
+
+// pasteCountDataSourceModel maps the data source schema data.
+type pasteCountDataSourceModel struct {
+	Format     types.String `tfsdk:"format"`
+	Visibility types.String `tfsdk:"visibility"`
+	Total      types.Int64  `tfsdk:"total"`
+}
+
+// Metadata returns the data source type name.
+func (d *pasteCountDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_paste_count"
+}
+
+// Schema defines the schema for the data source.
+func (d *pasteCountDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"format": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					pasteFormat(),
+				},
+			},
+			"visibility": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringOneOf("public", "unlisted", "private"),
+				},
+			},
+			"total": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ConfigValidators returns the validators for the data source configuration.
+func (d *pasteCountDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		requiresUserKey(d.client, "Counting the pastes of a user"),
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *pasteCountDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *pasteCountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pasteCountDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Counting pastes requires an authenticated user
+	if d.client.client.UserKey == "" {
+		resp.Diagnostics.Append(missingUserKeyError("Counting the pastes of a user"))
+		return
+	}
+
+	// The list api has no paging, so the maximum number of results is all
+	// that can be counted
+	pastes, err := d.client.ListPastes(ctx, maxListResults)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Pastebin Pastes",
+			"Could not list the pastes of the user: "+err.Error(),
+		)
+		return
+	}
+	if len(pastes) >= maxListResults {
+		resp.Diagnostics.AddWarning(
+			"Incomplete Pastebin Paste Count",
+			fmt.Sprintf("The user has at least %d pastes, which is the maximum number of pastes the PasteBin API lists. "+
+				"Pastes beyond this limit are not counted.", maxListResults),
+		)
+	}
+
+	count := int64(0)
+	for _, paste := range pastes {
+		if !state.Format.IsNull() && paste.FormatShort != state.Format.ValueString() {
+			continue
+		}
+		if !state.Visibility.IsNull() && pasteVisibility(paste.Private) != state.Visibility.ValueString() {
+			continue
+		}
+		count++
+	}
+	state.Total = types.Int64Value(count)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
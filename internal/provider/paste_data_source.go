@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &pasteDataSource{}
+	_ datasource.DataSourceWithConfigure = &pasteDataSource{}
+)
+
+// NewPasteDataSource is a helper function to simplify the provider implementation.
+func NewPasteDataSource() datasource.DataSource {
+	return &pasteDataSource{}
+}
+
+// pasteDataSource is the data source implementation.
+type pasteDataSource struct {
+	client *pastebinClient
+}
+
+// pasteDataSourceModel maps the data source schema data.
+type pasteDataSourceModel struct {
+	Key        types.String `tfsdk:"key"`
+	Content    types.String `tfsdk:"content"`
+	Title      types.String `tfsdk:"title"`
+	Format     types.String `tfsdk:"format"`
+	Visibility types.String `tfsdk:"visibility"`
+	Owner      types.String `tfsdk:"owner"`
+	Hits       types.Int64  `tfsdk:"hits"`
+}
+
+// Metadata returns the data source type name.
+func (d *pasteDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_paste"
+}
+
+// Schema defines the schema for the data source.
+func (d *pasteDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			// The key is extracted from a paste url, so a url such as the address of
+			// a paste or its raw content in the browser can be used as key.
+			"key": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					pasteKeyOrUrl(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Computed: true,
+			},
+			// The metadata of a paste is only known for pastes of the configured user.
+			"title": schema.StringAttribute{
+				Computed: true,
+			},
+			"format": schema.StringAttribute{
+				Computed: true,
+			},
+			"visibility": schema.StringAttribute{
+				Computed: true,
+			},
+			// The owner is the name of the configured user if the paste is theirs.
+			"owner": schema.StringAttribute{
+				Computed: true,
+			},
+			// Pastebin only reports the hits of the pastes of the configured user.
+			"hits": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *pasteDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *pasteDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pasteDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The key was already validated, unless it was unknown during validation
+	pasteKey, err := parsePasteKey(state.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key"),
+			"Invalid Pastebin Paste Key",
+			"The key must be a paste key such as abcd1234 or a paste url such as https://pastebin.com/abcd1234, but the "+err.Error()+".",
+		)
+		return
+	}
+
+	// A paste url of another Pastebin instance is read by its key from the
+	// configured host, which is likely not what was intended
+	if host := pasteUrlHost(state.Key.ValueString()); host != "" && !sameHost(host, d.client.client.Host.Hostname()) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("key"),
+			"Pastebin Paste Url Host Mismatch",
+			"The paste url points to "+host+", but the provider is configured with the host "+d.client.client.Host.Hostname()+". "+
+				"The paste "+pasteKey+" is read from the configured host.",
+		)
+	}
+
+	// Get the raw paste content from Pastebin, of which public and unlisted
+	// pastes of other users can only be read anonymously
+	content, err := d.client.GetPaste(ctx, pasteKey)
+	if errors.Is(err, errPasteNotFound) && d.client.client.UserKey != "" {
+		content, err = d.client.GetPublicPaste(ctx, pasteKey)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key"),
+			"Unable to Read Pastebin Paste",
+			"The paste with key "+pasteKey+" does not exist, or it is private and not accessible with the configured user_key.\n\n"+
+				"PasteBin Client Error: "+err.Error(),
+		)
+		return
+	}
+	state.Content = types.StringValue(content)
+
+	// Get the paste metadata from the pastes of the user, which are unknown
+	// to a guest
+	state.Title = types.StringNull()
+	state.Format = types.StringNull()
+	state.Visibility = types.StringNull()
+	state.Owner = types.StringNull()
+	state.Hits = types.Int64Null()
+	if d.client.client.UserKey == "" {
+		diags = resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	paste, err := d.client.FindPaste(ctx, pasteKey)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Pastebin Paste Metadata",
+			"Could not list the pastes of the user to read the metadata of paste "+pasteKey+": "+err.Error(),
+		)
+		return
+	}
+	if paste != nil {
+		state.Title = types.StringValue(paste.Title)
+		state.Format = types.StringValue(paste.FormatShort)
+		state.Visibility = types.StringValue(pasteVisibility(paste.Private))
+		state.Hits = types.Int64Value(paste.Hits)
+
+		// The list only contains pastes of the user, who owns the paste
+		details, err := d.client.GetUserDetails(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Pastebin User",
+				"Could not read the account details of the user to read the owner of paste "+pasteKey+": "+err.Error(),
+			)
+			return
+		}
+		state.Owner = types.StringValue(details.Name)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
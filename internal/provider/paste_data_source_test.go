@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-pastebin/internal/pastebintest"
+)
+
+func TestPasteDataSourceReadOwner(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	owned := server.AddPaste(pastebintest.Paste{Content: "Owned by the user.", Private: "2", Owner: server.UserKey, Hits: 42})
+	foreign := server.AddPaste(pastebintest.Paste{Content: "Owned by another user.", Private: "0", Owner: "other", Hits: 7})
+
+	testCases := map[string]struct {
+		providerConfig map[string]tftypes.Value
+		key            string
+		content        string
+		owner          tftypes.Value
+		hits           tftypes.Value
+	}{
+		"owned": {
+			providerConfig: testFakeProviderConfig(server),
+			key:            owned,
+			content:        "Owned by the user.",
+			owner:          tftypes.NewValue(tftypes.String, server.UserName),
+			hits:           tftypes.NewValue(tftypes.Number, 42),
+		},
+		"foreign": {
+			providerConfig: testFakeProviderConfig(server),
+			key:            foreign,
+			content:        "Owned by another user.",
+			owner:          tftypes.NewValue(tftypes.String, nil),
+			hits:           tftypes.NewValue(tftypes.Number, nil),
+		},
+		"anonymous": {
+			providerConfig: map[string]tftypes.Value{
+				"host":        tftypes.NewValue(tftypes.String, server.URL),
+				"dev_key":     tftypes.NewValue(tftypes.String, server.DevKey),
+				"max_retries": tftypes.NewValue(tftypes.Number, 0),
+			},
+			key:     foreign,
+			content: "Owned by another user.",
+			owner:   tftypes.NewValue(tftypes.String, nil),
+			hits:    tftypes.NewValue(tftypes.Number, nil),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes, diagnostics := testReadDataSource(t, testCase.providerConfig, "pastebin_paste", map[string]tftypes.Value{
+				"key": tftypes.NewValue(tftypes.String, testCase.key),
+			})
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			if !attributes["content"].Equal(tftypes.NewValue(tftypes.String, testCase.content)) {
+				t.Errorf("expected content %q, got %v", testCase.content, attributes["content"])
+			}
+			if !attributes["owner"].Equal(testCase.owner) {
+				t.Errorf("expected owner %v, got %v", testCase.owner, attributes["owner"])
+			}
+			if !attributes["hits"].Equal(testCase.hits) {
+				t.Errorf("expected hits %v, got %v", testCase.hits, attributes["hits"])
+			}
+		})
+	}
+}
+
+func TestPasteDataSourceReadUrl(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	key := server.AddPaste(pastebintest.Paste{Content: "Hello from Terraform.", Private: "0", Owner: "other"})
+
+	testCases := map[string]struct {
+		key         string
+		expectWarns bool
+	}{
+		"key":           {key: key},
+		"url":           {key: server.URL + "/" + key},
+		"raw url":       {key: server.URL + "/raw/" + key},
+		"raw path":      {key: "/raw/" + key},
+		"other host":    {key: "https://pastebin.com/" + key, expectWarns: true},
+		"other raw url": {key: "pastebin.com/raw/" + key, expectWarns: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes, diagnostics := testReadDataSource(t, testFakeProviderConfig(server), "pastebin_paste", map[string]tftypes.Value{
+				"key": tftypes.NewValue(tftypes.String, testCase.key),
+			})
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			if !attributes["content"].Equal(tftypes.NewValue(tftypes.String, "Hello from Terraform.")) {
+				t.Errorf("unexpected content %v", attributes["content"])
+			}
+			warned := len(diagnostics) > 0 && diagnostics[0].Summary == "Pastebin Paste Url Host Mismatch"
+			if warned != testCase.expectWarns {
+				t.Errorf("expected a host mismatch warning %t, got %v", testCase.expectWarns, diagnostics)
+			}
+		})
+	}
+}
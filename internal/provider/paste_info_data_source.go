@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &pasteInfoDataSource{}
+	_ datasource.DataSourceWithConfigure        = &pasteInfoDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &pasteInfoDataSource{}
+)
+
+// NewPasteInfoDataSource is a helper function to simplify the provider implementation.
+func NewPasteInfoDataSource() datasource.DataSource {
+	return &pasteInfoDataSource{}
+}
+
+// pasteInfoDataSource is the data source implementation.
+type pasteInfoDataSource struct {
+	client *pastebinClient
+}
+
+// pasteInfoDataSourceModel maps the data source schema data.
+type pasteInfoDataSourceModel struct {
+	Key        types.String `tfsdk:"key"`
+	Title      types.String `tfsdk:"title"`
+	Date       types.String `tfsdk:"date"`
+	Size       types.Int64  `tfsdk:"size"`
+	Visibility types.String `tfsdk:"visibility"`
+	Format     types.String `tfsdk:"format"`
+	Expiration types.String `tfsdk:"expiration"`
+	Hits       types.Int64  `tfsdk:"hits"`
+}
+
+// Metadata returns the data source type name.
+func (d *pasteInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_paste_info"
+}
+
+// Schema defines the schema for the data source.
+func (d *pasteInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"key": schema.StringAttribute{
+				Required: true,
+			},
+			"title": schema.StringAttribute{
+				Computed: true,
+			},
+			"date": schema.StringAttribute{
+				Computed: true,
+			},
+			"size": schema.Int64Attribute{
+				Computed: true,
+			},
+			"visibility": schema.StringAttribute{
+				Computed: true,
+			},
+			"format": schema.StringAttribute{
+				Computed: true,
+			},
+			"expiration": schema.StringAttribute{
+				Computed: true,
+			},
+			"hits": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ConfigValidators returns the validators for the data source configuration.
+func (d *pasteInfoDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		requiresUserKey(d.client, "Reading the metadata of a paste"),
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *pasteInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *pasteInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pasteInfoDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The metadata of a paste is only listed for an authenticated user
+	if d.client.client.UserKey == "" {
+		resp.Diagnostics.Append(missingUserKeyError("Reading the metadata of a paste"))
+		return
+	}
+
+	// Get the paste metadata from the pastes of the user, without its content
+	paste, err := d.client.FindPaste(ctx, state.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Pastebin Paste Metadata",
+			"Could not list the pastes of the user to read the metadata of paste "+state.Key.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if paste == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key"),
+			"Pastebin Paste Not Found",
+			"The paste with key "+state.Key.ValueString()+" is not one of the pastes of the user. "+
+				"The metadata of a paste can only be read by its owner.",
+		)
+		return
+	}
+
+	// Map response body to model
+	state.Title = types.StringValue(paste.Title)
+	state.Date = unixTimestamp(paste.Date)
+	state.Size = types.Int64Value(paste.Size)
+	state.Visibility = types.StringValue(pasteVisibility(paste.Private))
+	state.Format = types.StringValue(paste.FormatShort)
+	state.Expiration = unixTimestamp(paste.ExpireDate)
+	state.Hits = types.Int64Value(paste.Hits)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,1378 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &pasteResource{}
+	_ resource.ResourceWithConfigure        = &pasteResource{}
+	_ resource.ResourceWithImportState      = &pasteResource{}
+	_ resource.ResourceWithModifyPlan       = &pasteResource{}
+	_ resource.ResourceWithConfigValidators = &pasteResource{}
+	_ resource.ResourceWithValidateConfig   = &pasteResource{}
+)
+
+// NewPasteResource is a helper function to simplify the provider implementation.
+func NewPasteResource() resource.Resource {
+	return &pasteResource{}
+}
+
+// pasteResource is the resource implementation.
+type pasteResource struct {
+	client       *pastebinClient
+	providerData *pastebinProviderData
+}
+
+// pasteResourceModel maps the resource schema data.
+type pasteResourceModel struct {
+	ID                       types.String `tfsdk:"id"`
+	Content                  types.String `tfsdk:"content"`
+	ContentBase64            types.String `tfsdk:"content_base64"`
+	ContentSensitive         types.Bool   `tfsdk:"content_sensitive"`
+	SourceFile               types.String `tfsdk:"source_file"`
+	ContentHash              types.String `tfsdk:"content_hash"`
+	ContentSha256            types.String `tfsdk:"content_sha256"`
+	SizeBytes                types.Int64  `tfsdk:"size_bytes"`
+	Title                    types.String `tfsdk:"title"`
+	GenerateTitleFromContent types.Bool   `tfsdk:"generate_title_from_content"`
+	Expire                   types.String `tfsdk:"expire"`
+	Visibility               types.String `tfsdk:"visibility"`
+	Format                   types.String `tfsdk:"format"`
+	AutoDetectFormat         types.Bool   `tfsdk:"auto_detect_format"`
+	ContentCharset           types.String `tfsdk:"content_charset"`
+	RecreateOnUpdate         types.Bool   `tfsdk:"recreate_on_update"`
+	MatchExistingByTitle     types.Bool   `tfsdk:"match_existing_by_title"`
+	CreateIfAbsent           types.Bool   `tfsdk:"create_if_absent"`
+	NormalizeLineEndings     types.Bool   `tfsdk:"normalize_line_endings"`
+	TrimTrailingWhitespace   types.Bool   `tfsdk:"trim_trailing_whitespace"`
+	Folder                   types.String `tfsdk:"folder"`
+	Keepers                  types.Map    `tfsdk:"keepers"`
+	Protected                types.Bool   `tfsdk:"protected"`
+	Url                      types.String `tfsdk:"url"`
+	RawUrl                   types.String `tfsdk:"raw_url"`
+	CreatedAt                types.String `tfsdk:"created_at"`
+	ExpiresAt                types.String `tfsdk:"expires_at"`
+	Timeouts                 types.Object `tfsdk:"timeouts"`
+}
+
+// pasteOptions returns the options to create the paste with.
+func (m pasteResourceModel) pasteOptions() pasteOptions {
+	return pasteOptions{
+		Title:      m.Title.ValueString(),
+		Private:    pasteVisibilities[m.Visibility.ValueString()],
+		ExpireDate: m.expire(),
+		Format:     m.Format.ValueString(),
+		FolderKey:  m.Folder.ValueString(),
+		Charset:    m.charset(),
+	}
+}
+
+// contentPath returns the path of the attribute that sets the content of the
+// paste.
+func (m pasteResourceModel) contentPath() path.Path {
+	if !m.SourceFile.IsNull() {
+		return path.Root("source_file")
+	}
+	if !m.ContentBase64.IsNull() {
+		return path.Root("content_base64")
+	}
+	return path.Root("content")
+}
+
+// charset returns the charset that the content of the paste is sent and read
+// in, or an empty string for UTF-8. Base64 content is uploaded as is.
+func (m pasteResourceModel) charset() string {
+	if !m.ContentBase64.IsNull() || m.ContentCharset.ValueString() == defaultCharset {
+		return ""
+	}
+	return m.ContentCharset.ValueString()
+}
+
+// expiresAt returns when the paste expires, computed from its creation date
+// and expiration, or null if it never expires or the creation date is unknown.
+func (m pasteResourceModel) expiresAt() types.String {
+	created, err := time.Parse(time.RFC3339, m.CreatedAt.ValueString())
+	if err != nil {
+		return types.StringNull()
+	}
+	expires, ok := expireTime(created, m.expire())
+	if !ok {
+		return types.StringNull()
+	}
+	return types.StringValue(expires.UTC().Format(time.RFC3339))
+}
+
+// expire returns the api_paste_expire_date value of the expiration, which may
+// be configured as a human-friendly alias.
+func (m pasteResourceModel) expire() string {
+	expire, _ := normalizeExpire(m.Expire.ValueString())
+	return expire
+}
+
+// content returns the content of the paste, which is read from the source
+// file or decoded from base64 if configured. The line endings are normalized
+// if configured, except for base64 encoded content which is used as is.
+func (m pasteResourceModel) content() (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !m.ContentBase64.IsNull() {
+		content, err := decodeContentBase64(m.ContentBase64.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("content_base64"),
+				"Invalid Base64 Paste Content",
+				"The content_base64 value must be standard base64 encoded, padded content that is not empty, but "+err.Error()+".",
+			)
+		}
+		return content, diags
+	}
+	if m.SourceFile.IsNull() {
+		return m.normalize(m.Content.ValueString()), diags
+	}
+
+	content, err := os.ReadFile(m.SourceFile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("source_file"),
+			"Unable to Read Paste Source File",
+			"Could not read the paste content from "+m.SourceFile.ValueString()+": "+err.Error(),
+		)
+		return "", diags
+	}
+	if strings.TrimSpace(string(content)) == "" {
+		diags.AddAttributeError(
+			path.Root("source_file"),
+			"Empty Paste Source File",
+			"The source file "+m.SourceFile.ValueString()+" is empty or contains only whitespace, which Pastebin rejects.",
+		)
+	}
+	return m.normalize(string(content)), diags
+}
+
+// decodeContentBase64 returns the content of the standard base64 encoded
+// value, or an error that describes where the value is invalid.
+func decodeContentBase64(value string) (string, error) {
+	content, err := base64.StdEncoding.DecodeString(value)
+	var corruptErr base64.CorruptInputError
+	if errors.As(err, &corruptErr) {
+		return "", fmt.Errorf("the value is not valid base64 at byte %d", int64(corruptErr))
+	}
+	if err != nil {
+		return "", fmt.Errorf("the value is not valid base64: %w", err)
+	}
+	if strings.TrimSpace(string(content)) == "" {
+		return "", errors.New("the decoded content is empty or contains only whitespace, which Pastebin rejects")
+	}
+	return string(content), nil
+}
+
+// contentSourceKey is the private state key that records the attribute the
+// content of the paste was configured with, if not content or source_file.
+const contentSourceKey = "content_source"
+
+// contentSourceBase64 is the private state value of content configured with
+// content_base64.
+var contentSourceBase64 = []byte(`"content_base64"`)
+
+// contentSource returns the private state value that records the attribute
+// the content of the paste is configured with, which is nil unless the content
+// is configured with content_base64.
+func (m pasteResourceModel) contentSource() []byte {
+	if m.ContentBase64.IsNull() {
+		return nil
+	}
+	return contentSourceBase64
+}
+
+// normalize returns the content with normalized line endings if configured,
+// or the content as is otherwise.
+func (m pasteResourceModel) normalize(content string) string {
+	if !m.NormalizeLineEndings.ValueBool() {
+		return content
+	}
+	return normalizeLineEndings(content)
+}
+
+// matches returns whether the content read from Pastebin matches the
+// configured content, which ignores trailing whitespace if configured.
+func (m pasteResourceModel) matches(configured, content string) bool {
+	if !m.TrimTrailingWhitespace.ValueBool() {
+		return configured == content
+	}
+	return strings.TrimRightFunc(configured, unicode.IsSpace) == strings.TrimRightFunc(content, unicode.IsSpace)
+}
+
+// normalizeLineEndings returns the content with all CRLF and CR line endings
+// replaced by LF.
+func normalizeLineEndings(content string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(content, "\r\n", "\n"), "\r", "\n")
+}
+
+// changed returns whether the content, expiration, visibility, format or
+// charset of the paste in the plan differs from the paste in the state.
+func (m pasteResourceModel) changed(state pasteResourceModel) bool {
+	return !m.Content.Equal(state.Content) || !m.ContentBase64.Equal(state.ContentBase64) || !m.ContentHash.Equal(state.ContentHash) ||
+		m.expire() != state.expire() || !m.Visibility.Equal(state.Visibility) || !m.Format.Equal(state.Format) ||
+		m.charset() != state.charset()
+}
+
+// providerDefault returns the provider default value, or null if the provider
+// has no default.
+func providerDefault(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// contentHash returns the hex encoded SHA256 hash of the content.
+func contentHash(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+// The default timeouts of the operations on a paste, which include the
+// retries and rate limiting of the api requests.
+const (
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 2 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 2 * time.Minute
+)
+
+// createdAtAttempts is the number of times the pastes of the user are listed
+// to find the creation date of a new paste, which may not be listed right away.
+const createdAtAttempts = 3
+
+// createdAtDelay is the delay between listing the pastes of the user.
+const createdAtDelay = time.Second
+
+// createdAt returns the creation date of the paste with the given key, or null
+// if the paste is not found in the pastes of the user.
+func (r *pasteResource) createdAt(ctx context.Context, pasteKey string) types.String {
+	if r.client.client.UserKey == "" {
+		return types.StringNull()
+	}
+	for attempt := 0; attempt < createdAtAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return types.StringNull()
+			case <-time.After(createdAtDelay):
+			}
+		}
+		paste, err := r.client.FindPaste(ctx, pasteKey)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to list the pastes of the user to find the creation date", map[string]interface{}{
+				"paste_key": pasteKey,
+				"error":     err.Error(),
+			})
+			return types.StringNull()
+		}
+		if paste != nil {
+			return unixTimestamp(paste.Date)
+		}
+	}
+	return types.StringNull()
+}
+
+// Metadata returns the resource type name.
+func (r *pasteResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_paste"
+}
+
+// Schema defines the schema for the resource.
+func (r *pasteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			// Pastebin has no api to edit a paste, so any change requires a new paste.
+			"content": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringNotBlank(),
+				},
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessRecreateOnUpdate(),
+				},
+			},
+			// Decoded content is uploaded as is. Read only refreshes the encoded
+			// value of pastes that were created from it.
+			"content_base64": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringBase64(),
+				},
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessRecreateOnUpdate(),
+				},
+			},
+			// Sensitivity is fixed in the schema, so a sensitive content is only kept
+			// out of the provider logs. Wrap the value in sensitive() to hide it in plans.
+			"content_sensitive": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			// The file is read during plan, so a change to the file surfaces as a
+			// change of the content hash.
+			"source_file": schema.StringAttribute{
+				Optional: true,
+			},
+			"content_hash": schema.StringAttribute{
+				Computed: true,
+			},
+			"content_sha256": schema.StringAttribute{
+				Computed: true,
+			},
+			"size_bytes": schema.Int64Attribute{
+				Computed: true,
+			},
+			// An unset title is derived from the content before the replacement is
+			// planned, so a derived title that changes replaces the paste as well.
+			"title": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringTruncated(maxPasteTitleLength),
+				},
+				PlanModifiers: []planmodifier.String{
+					titleFromContent(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"generate_title_from_content": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			// Pastebin never returns the expiration, so read keeps the configured value.
+			// If unset, the provider default_expire is used on create. Recreating the
+			// paste on update restarts the expiration. An alias such as "1 week" is
+			// the same expiration as its code, so changing between them is an update
+			// of the state only.
+			"expire": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					pasteExpire(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					expireRequiresReplaceUnlessRecreateOnUpdate(),
+				},
+			},
+			// Pastebin never returns the visibility, so read keeps the configured value.
+			// If unset, the provider default_visibility or unlisted is used on create.
+			"visibility": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringOneOf("public", "unlisted", "private"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					requiresReplaceUnlessRecreateOnUpdate(),
+				},
+			},
+			// Pastebin does not return the format on a raw read, so read keeps the configured value.
+			// If unset, the provider default_format is used on create.
+			"format": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					pasteFormat(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					requiresReplaceUnlessRecreateOnUpdate(),
+				},
+			},
+			// Pastebin stores the content in this charset, which content and
+			// source_file are transcoded to on upload and from on read.
+			"content_charset": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultCharset),
+				Validators: []validator.String{
+					stringOneOf(pasteCharsetNames...),
+				},
+				PlanModifiers: []planmodifier.String{
+					charsetRequiresReplaceUnlessRecreateOnUpdate(),
+				},
+			},
+			// Detects the format of a new paste from the extension of its source_file
+			// if format is unset, instead of using the provider default_format.
+			"auto_detect_format": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			// Pastebin does not return the folder on a raw read, so read keeps the configured value.
+			"folder": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			// Arbitrary values that recreate the paste when any of them changes,
+			// such as a hash of a configuration that the content is derived from.
+			"keepers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			// Normalized content is stored with the configured line endings, as long
+			// as the paste only differs from the configuration in its line endings.
+			"normalize_line_endings": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			// Content is stored as configured, as long as the paste only differs from
+			// the configuration in its trailing whitespace, which Pastebin may trim.
+			"trim_trailing_whitespace": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			// Recreating the paste on update keeps the resource, but changes its id.
+			// It applies to changes of the content, expire, visibility and format.
+			"recreate_on_update": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			// Adopts a paste of the user with the same title and content on create,
+			// instead of creating a duplicate paste.
+			"match_existing_by_title": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			// Adopts a paste of the user with the same content on create, instead
+			// of storing the content again.
+			"create_if_absent": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			// A protected paste cannot be destroyed until protected is set to false
+			// and applied, for configurations that cannot use prevent_destroy.
+			"protected": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"raw_url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			// The creation date is only known for pastes of the configured user.
+			"created_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			// The expiration date is computed from created_at, so it is only
+			// known for pastes of the configured user that expire.
+			"expires_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+// ConfigValidators returns the validators for the resource configuration.
+func (r *pasteResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		exactlyOneOf(path.Root("content"), path.Root("content_base64"), path.Root("source_file")),
+		requiresUserKeyIf(r.client, "Creating a private paste", path.Root("visibility"), "private"),
+	}
+}
+
+// ValidateConfig warns about combinations of attributes that cannot be
+// resolved during plan, or that have no effect, as these would otherwise only
+// surface after apply.
+func (r *pasteResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config pasteResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	contentUnknown := config.Content.IsUnknown() || config.ContentBase64.IsUnknown() || config.SourceFile.IsUnknown()
+
+	// A title derived from unknown content is unknown during plan, and a
+	// change of the title recreates the paste
+	if config.GenerateTitleFromContent.ValueBool() && config.Title.IsNull() && contentUnknown {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("generate_title_from_content"),
+			"Pastebin Paste Title Unknown Until Apply",
+			"The title is generated from paste content that is not known until apply, so the title is unknown during plan. "+
+				"As a change of the title recreates the paste, an existing paste is planned to be replaced whenever its content is unknown. "+
+				"Configure the title to avoid this.",
+		)
+	}
+	if config.GenerateTitleFromContent.ValueBool() && !config.Title.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("generate_title_from_content"),
+			"Pastebin Paste Title Not Generated",
+			"The title is configured, so generate_title_from_content has no effect.",
+		)
+	}
+
+	// A format detected from an unknown source file is unknown during plan
+	if config.AutoDetectFormat.ValueBool() && config.Format.IsNull() && config.SourceFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("auto_detect_format"),
+			"Pastebin Paste Format Unknown Until Apply",
+			"The format is detected from a source_file that is not known until apply, so the format is unknown during plan. "+
+				"Configure the format to know it during plan.",
+		)
+	}
+	if config.AutoDetectFormat.ValueBool() && (!config.Format.IsNull() || config.SourceFile.IsNull()) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("auto_detect_format"),
+			"Pastebin Paste Format Not Detected",
+			"The format is only detected from the extension of a source_file if the format is not configured, so auto_detect_format has no effect.",
+		)
+	}
+
+	// A visibility that is unknown during plan may turn out to be private,
+	// which requires a user key. The provider configuration is not known
+	// while validating the resource configuration without a plan.
+	if config.Visibility.IsUnknown() && r.client != nil && r.client.client.UserKey == "" {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("visibility"),
+			"Pastebin Paste Visibility Unknown Until Apply",
+			"The visibility is not known until apply, and the provider is configured without a user key. "+
+				"If the visibility turns out to be private, creating the paste fails during apply, as private pastes require an authenticated user. "+
+				"Set the user_key value in the provider configuration or use the PASTEBIN_USER_KEY environment variable.",
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *pasteResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *pasteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan pasteResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := operationTimeout(plan.Timeouts, "create", defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	content, diags := plan.content()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The title is derived before the paste is created, if it is derived
+	// from content that was unknown during plan
+	if plan.Title.IsUnknown() {
+		plan.Title = types.StringNull()
+		if plan.GenerateTitleFromContent.ValueBool() {
+			plan.Title = types.StringValue(pasteTitleFromContent(content))
+		}
+	}
+
+	// The format is detected before the paste is created, if it is detected
+	// from a source file that was unknown during plan
+	if plan.Format.IsUnknown() && plan.AutoDetectFormat.ValueBool() && !plan.SourceFile.IsNull() {
+		plan.Format = types.StringValue(formatFromExtension(plan.SourceFile.ValueString()))
+	}
+
+	// Adopt an existing paste with the same title and content, if requested
+	if plan.MatchExistingByTitle.ValueBool() {
+		pasteKey, diags := r.matchExistingByTitle(ctx, plan, content)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if pasteKey != "" {
+			r.setCreatedState(ctx, plan, pasteKey, content, resp)
+			return
+		}
+	}
+
+	// Adopt an existing paste with the same content, if requested
+	if plan.CreateIfAbsent.ValueBool() {
+		pasteKey, diags := r.findByContent(ctx, plan, content)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if pasteKey != "" {
+			r.setCreatedState(ctx, plan, pasteKey, content, resp)
+			return
+		}
+	}
+
+	// Create new paste
+	if plan.ContentSensitive.ValueBool() {
+		ctx = withSensitiveContent(ctx)
+	}
+	pasteKey, err := r.client.CreatePaste(ctx, content, plan.pasteOptions())
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.Err == nil && plan.Folder.ValueString() != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("folder"),
+			"Error Creating Pastebin Paste in Folder",
+			"Could not create paste in folder "+plan.Folder.ValueString()+". "+
+				"Folders are only available for Pastebin PRO accounts, and the folder must exist for the user.\n\n"+
+				"Pastebin API Error: "+apiErr.Message,
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not create paste: %w", err)))
+		return
+	}
+
+	r.setCreatedState(ctx, plan, pasteKey, content, resp)
+}
+
+// setCreatedState sets the state of the created or adopted paste with the
+// given key and content.
+func (r *pasteResource) setCreatedState(ctx context.Context, plan pasteResourceModel, pasteKey, content string, resp *resource.CreateResponse) {
+	// Map response to schema
+	plan.ID = types.StringValue(pasteKey)
+	plan.ContentSha256 = types.StringValue(contentHash(content))
+	plan.SizeBytes = types.Int64Value(int64(len(content)))
+	plan.Url = types.StringValue(r.client.PasteUrl(pasteKey))
+	plan.RawUrl = types.StringValue(r.client.RawPasteUrl(pasteKey))
+	plan.CreatedAt = r.createdAt(ctx, pasteKey)
+	plan.ExpiresAt = plan.expiresAt()
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, contentSourceKey, plan.contentSource())...)
+}
+
+// findByContent returns the key of a paste of the user with the content,
+// visibility and format of the plan, or an empty key if there is none. Only
+// the content of the pastes with the expected size is read.
+func (r *pasteResource) findByContent(ctx context.Context, plan pasteResourceModel, content string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if r.client.client.UserKey == "" {
+		diags.Append(diag.WithPath(path.Root("create_if_absent"), missingUserKeyError("Finding existing pastes by content")))
+		return "", diags
+	}
+
+	// Pastebin stores the content in its charset, and may trim its trailing
+	// whitespace, in which case the size is not known in advance
+	size := int64(-1)
+	if !plan.TrimTrailingWhitespace.ValueBool() {
+		uploaded := content
+		if charset := plan.charset(); charset != "" {
+			var err error
+			uploaded, err = encodeCharset(charset, content)
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("content_charset"),
+					"Unable to Encode Pastebin Paste",
+					"Could not encode the content of the paste: "+err.Error(),
+				)
+				return "", diags
+			}
+		}
+		size = int64(len(uploaded))
+	}
+
+	pastes, err := r.client.ListPastes(ctx, maxListResults)
+	if err != nil {
+		diags.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not list the pastes of the user to find the content: %w", err)))
+		return "", diags
+	}
+	if len(pastes) >= maxListResults {
+		tflog.Warn(ctx, "The user has more pastes than can be listed, so a paste with the same content may not be found", nil)
+	}
+	for _, paste := range pastes {
+		if (size >= 0 && paste.Size != size) || !plan.sameSettings(paste) {
+			continue
+		}
+		remote, err := r.readContent(ctx, plan, paste.Key)
+		if errors.Is(err, errPasteNotFound) {
+			continue
+		}
+		if err != nil {
+			diags.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not read paste %s to compare it with the content: %w", paste.Key, err)))
+			return "", diags
+		}
+		if plan.matches(content, remote) {
+			tflog.Info(ctx, "Adopting the existing paste with the same content", map[string]interface{}{
+				"paste_key": paste.Key,
+			})
+			return paste.Key, diags
+		}
+	}
+	return "", diags
+}
+
+// sameSettings returns whether the listed paste has the visibility and format
+// of the plan. A format that is not planned is not compared.
+func (m pasteResourceModel) sameSettings(paste pasteListItem) bool {
+	return pasteVisibility(paste.Private) == m.Visibility.ValueString() &&
+		(m.Format.IsNull() || paste.FormatShort == m.Format.ValueString())
+}
+
+// readContent returns the content of the paste with the given key, decoded
+// from the charset of the plan.
+func (r *pasteResource) readContent(ctx context.Context, plan pasteResourceModel, pasteKey string) (string, error) {
+	charset := plan.charset()
+	if charset == "" {
+		return r.client.GetPaste(ctx, pasteKey)
+	}
+	content, err := r.client.GetPaste(withCharset(ctx, charset), pasteKey)
+	if err != nil {
+		return "", err
+	}
+	return decodeCharset(charset, content)
+}
+
+// matchExistingByTitle returns the key of the paste of the user with the
+// title of the plan, if it has the content, visibility and format of the
+// plan. A paste with the title that differs is deleted, so the paste is
+// recreated, and an empty key is returned. Multiple pastes with the title
+// are an error, as it is unclear which one to adopt.
+func (r *pasteResource) matchExistingByTitle(ctx context.Context, plan pasteResourceModel, content string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if plan.Title.IsNull() || plan.Title.ValueString() == "" {
+		return "", diags
+	}
+	if r.client.client.UserKey == "" {
+		diags.Append(diag.WithPath(path.Root("match_existing_by_title"), missingUserKeyError("Matching existing pastes by title")))
+		return "", diags
+	}
+
+	pastes, err := r.client.ListPastes(ctx, maxListResults)
+	if err != nil {
+		diags.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not list the pastes of the user to match the title: %w", err)))
+		return "", diags
+	}
+	if len(pastes) >= maxListResults {
+		tflog.Warn(ctx, "The user has more pastes than can be listed, so a paste with the same title may not be found", map[string]interface{}{
+			"title": plan.Title.ValueString(),
+		})
+	}
+	var matches []pasteListItem
+	for _, paste := range pastes {
+		if paste.Title == plan.Title.ValueString() {
+			matches = append(matches, paste)
+		}
+	}
+	if len(matches) == 0 {
+		return "", diags
+	}
+	if len(matches) > 1 {
+		keys := make([]string, 0, len(matches))
+		for _, paste := range matches {
+			keys = append(keys, paste.Key)
+		}
+		diags.AddAttributeError(
+			path.Root("title"),
+			"Multiple Pastebin Pastes Match Title",
+			fmt.Sprintf("The title %q matches %d pastes of the user (%s), so no paste can be adopted. "+
+				"Delete the duplicate pastes, or set match_existing_by_title to false.", plan.Title.ValueString(), len(matches), strings.Join(keys, ", ")),
+		)
+		return "", diags
+	}
+	existing := matches[0]
+
+	// The content is only compared if the settings match
+	same := plan.sameSettings(existing)
+	if same {
+		remote, err := r.readContent(ctx, plan, existing.Key)
+		if err != nil {
+			diags.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not read paste %s to compare it with the content: %w", existing.Key, err)))
+			return "", diags
+		}
+		same = plan.matches(content, remote)
+	}
+	if same {
+		tflog.Info(ctx, "Adopting the existing paste with the same title and content", map[string]interface{}{
+			"paste_key": existing.Key,
+		})
+		return existing.Key, diags
+	}
+
+	tflog.Info(ctx, "Recreating the existing paste with the same title, as its content or settings differ", map[string]interface{}{
+		"paste_key": existing.Key,
+	})
+	if err := r.client.DeletePaste(ctx, existing.Key); err != nil && !errors.Is(err, errPasteNotFound) {
+		diags.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not delete paste %s to recreate it: %w", existing.Key, err)))
+	}
+	return "", diags
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *pasteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state pasteResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := operationTimeout(state.Timeouts, "read", defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Get refreshed paste content from Pastebin
+	charset := state.charset()
+	if charset != "" {
+		ctx = withCharset(ctx, charset)
+	}
+	// Public and unlisted pastes of other users can only be read anonymously
+	content, err := r.client.GetPaste(ctx, state.ID.ValueString())
+	if errors.Is(err, errPasteNotFound) && r.client.client.UserKey != "" {
+		content, err = r.client.GetPublicPaste(ctx, state.ID.ValueString())
+	}
+	if errors.Is(err, errPasteNotFound) && (state.Visibility.ValueString() != "private" || r.client.client.UserKey != "") {
+		// The paste expired or was deleted outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if errors.Is(err, errPasteNotFound) {
+		// A private paste is not found without a user key
+		resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not read paste %s, as a private paste can only be read with the user_key of its owner: %w", state.ID.ValueString(), err)))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not read paste %s: %w", state.ID.ValueString(), err)))
+		return
+	}
+	if charset != "" {
+		content, err = decodeCharset(charset, content)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content_charset"),
+				"Unable to Decode Pastebin Paste",
+				"Could not decode the content of paste "+state.ID.ValueString()+" from "+charset+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// The encoding of the paste content is unknown, so the content is only
+	// encoded as base64 again if the paste was created from base64 content
+	source, diags := req.Private.GetKey(ctx, contentSourceKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Overwrite content with refreshed state, or only its hash if the content
+	// is read from a source file. Content that only differs in its normalized
+	// line endings, or in its trailing whitespace if trimmed, is kept as
+	// configured, together with the hash and size that are planned for it.
+	var configured string
+	unchanged := false
+	switch {
+	case string(source) == string(contentSourceBase64):
+		decoded, err := decodeContentBase64(state.ContentBase64.ValueString())
+		configured, unchanged = decoded, err == nil && state.matches(decoded, content)
+		if !unchanged {
+			state.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString([]byte(content)))
+		}
+	case !state.ContentBase64.IsNull():
+		// Keep the configured value, as it cannot be compared with the content
+	case state.SourceFile.IsNull():
+		configured = state.normalize(state.Content.ValueString())
+		unchanged = state.matches(configured, content)
+		if !unchanged {
+			state.Content = types.StringValue(content)
+		}
+	default:
+		// A source file that can no longer be read is compared by its hash
+		file, diags := state.content()
+		configured, unchanged = file, !diags.HasError() && state.matches(file, content)
+		state.ContentHash = types.StringValue(contentHash(content))
+		if unchanged {
+			state.ContentHash = types.StringValue(contentHash(configured))
+		}
+	}
+	state.ContentSha256 = types.StringValue(contentHash(content))
+	state.SizeBytes = types.Int64Value(int64(len(content)))
+	if unchanged {
+		state.ContentSha256 = types.StringValue(contentHash(configured))
+		state.SizeBytes = types.Int64Value(int64(len(configured)))
+	}
+	state.Url = types.StringValue(r.client.PasteUrl(state.ID.ValueString()))
+	state.RawUrl = types.StringValue(r.client.RawPasteUrl(state.ID.ValueString()))
+	state.ExpiresAt = state.expiresAt()
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *pasteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan pasteResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state pasteResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := operationTimeout(plan.Timeouts, "update", defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Pastebin has no api to edit a paste, so a changed paste is recreated
+	// with the same settings and then the old paste is deleted, which a
+	// protected paste refuses.
+	if plan.changed(state) {
+		if state.Protected.ValueBool() {
+			resp.Diagnostics.Append(protectedError(state.ID.ValueString()))
+			return
+		}
+
+		content, diags := plan.content()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if plan.ContentSensitive.ValueBool() {
+			ctx = withSensitiveContent(ctx)
+		}
+		pasteKey, err := r.client.CreatePaste(ctx, content, plan.pasteOptions())
+		if err != nil {
+			resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not recreate paste %s: %w", state.ID.ValueString(), err)))
+			return
+		}
+		plan.ID = types.StringValue(pasteKey)
+		plan.ContentSha256 = types.StringValue(contentHash(content))
+		plan.SizeBytes = types.Int64Value(int64(len(content)))
+		plan.Url = types.StringValue(r.client.PasteUrl(pasteKey))
+		plan.RawUrl = types.StringValue(r.client.RawPasteUrl(pasteKey))
+		plan.CreatedAt = r.createdAt(ctx, pasteKey)
+		plan.ExpiresAt = plan.expiresAt()
+
+		if r.client.client.UserKey == "" {
+			err = errors.New("guest pastes cannot be deleted")
+		} else {
+			err = r.client.DeletePaste(ctx, state.ID.ValueString())
+		}
+		if err != nil && !errors.Is(err, errPasteNotFound) {
+			resp.Diagnostics.AddWarning(
+				"Error Deleting Previous Pastebin Paste",
+				"The paste was recreated as "+pasteKey+", but the previous paste "+state.ID.ValueString()+" could not be deleted: "+err.Error(),
+			)
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, contentSourceKey, plan.contentSource())...)
+}
+
+// ModifyPlan checks that a private paste can be created, hashes the content
+// of the paste and marks the id and urls as unknown when an update recreates
+// the paste.
+func (r *pasteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is planned on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan pasteResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unset settings of a new paste fall back to the provider defaults
+	if req.State.Raw.IsNull() && r.providerData != nil {
+		var format, expire, visibility types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("format"), &format)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expire"), &expire)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("visibility"), &visibility)...)
+		if format.IsNull() {
+			format = providerDefault(r.providerData.DefaultFormat)
+			if plan.AutoDetectFormat.ValueBool() && plan.SourceFile.IsUnknown() {
+				format = types.StringUnknown()
+			} else if plan.AutoDetectFormat.ValueBool() && !plan.SourceFile.IsNull() {
+				format = types.StringValue(formatFromExtension(plan.SourceFile.ValueString()))
+			}
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("format"), format)...)
+		}
+		if expire.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expire"), providerDefault(r.providerData.DefaultExpire))...)
+		}
+		if visibility.IsNull() {
+			plan.Visibility = types.StringValue("unlisted")
+			if r.providerData.DefaultVisibility != "" {
+				plan.Visibility = types.StringValue(r.providerData.DefaultVisibility)
+			}
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("visibility"), plan.Visibility)...)
+		}
+	}
+
+	// Private pastes are owned by a user, so they require a user key. The
+	// provider configuration is not known while validating the resource
+	// configuration, and the visibility may be the provider default, so this
+	// is checked during plan.
+	if r.client != nil && plan.Visibility.ValueString() == "private" && r.client.client.UserKey == "" {
+		resp.Diagnostics.Append(diag.WithPath(path.Root("visibility"), missingUserKeyError("Creating a private paste")))
+		return
+	}
+	// Matching existing pastes by title or content lists the pastes of the user
+	if r.client != nil && req.State.Raw.IsNull() && plan.MatchExistingByTitle.ValueBool() && r.client.client.UserKey == "" {
+		resp.Diagnostics.Append(diag.WithPath(path.Root("match_existing_by_title"), missingUserKeyError("Matching existing pastes by title")))
+		return
+	}
+	if r.client != nil && req.State.Raw.IsNull() && plan.CreateIfAbsent.ValueBool() && r.client.client.UserKey == "" {
+		resp.Diagnostics.Append(diag.WithPath(path.Root("create_if_absent"), missingUserKeyError("Finding existing pastes by content")))
+		return
+	}
+
+	// Only the hash of content that is read from a source file is stored
+	plan.ContentHash = types.StringNull()
+	plan.SizeBytes = types.Int64Unknown()
+	if plan.SourceFile.IsUnknown() {
+		plan.ContentHash = types.StringUnknown()
+	} else if !plan.SourceFile.IsNull() {
+		content, diags := plan.content()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.ContentHash = types.StringValue(contentHash(content))
+		plan.SizeBytes = types.Int64Value(int64(len(content)))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), plan.ContentHash)...)
+
+	// The hash and size of the paste content are known whenever its content
+	// is known
+	plan.ContentSha256 = types.StringUnknown()
+	if !plan.ContentHash.IsNull() {
+		plan.ContentSha256 = plan.ContentHash
+	} else if !plan.Content.IsUnknown() && !plan.Content.IsNull() {
+		content := plan.normalize(plan.Content.ValueString())
+		plan.ContentSha256 = types.StringValue(contentHash(content))
+		plan.SizeBytes = types.Int64Value(int64(len(content)))
+	} else if !plan.ContentBase64.IsUnknown() && !plan.ContentBase64.IsNull() {
+		content, diags := plan.content()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.ContentSha256 = types.StringValue(contentHash(content))
+		plan.SizeBytes = types.Int64Value(int64(len(content)))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_sha256"), plan.ContentSha256)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("size_bytes"), plan.SizeBytes)...)
+
+	// Fail during plan instead of halfway through an apply if the paste uses
+	// features that the account of the user does not support, unless the plan
+	// must not request the api
+	if r.providerData == nil || !r.providerData.SkipApiOnPlan {
+		resp.Diagnostics.Append(r.checkAccountFeatures(ctx, plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Large content is allowed, but slows down applies, so new content above
+	// the threshold is pointed out
+	var state pasteResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if r.providerData != nil && !plan.ContentSha256.Equal(state.ContentSha256) {
+		resp.Diagnostics.Append(largeContentWarning(plan, r.providerData.LargePasteWarnBytes)...)
+	}
+
+	// Nothing is recreated on create
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if !plan.ContentHash.Equal(state.ContentHash) && !plan.RecreateOnUpdate.ValueBool() {
+		resp.RequiresReplace.Append(path.Root("content_hash"))
+	}
+	if plan.RecreateOnUpdate.ValueBool() && plan.changed(state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("url"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("raw_url"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("created_at"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_at"), types.StringUnknown())...)
+	}
+}
+
+// largeContentWarning returns a warning if the planned size of the content
+// exceeds threshold bytes. A threshold of 0 disables the warning.
+func largeContentWarning(plan pasteResourceModel, threshold int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if threshold <= 0 || plan.SizeBytes.IsUnknown() || plan.SizeBytes.ValueInt64() <= threshold {
+		return diags
+	}
+	diags.AddAttributeWarning(
+		plan.contentPath(),
+		"Large Pastebin Paste",
+		fmt.Sprintf("The content of the paste is %d bytes, which exceeds the large_paste_warn_bytes threshold of %d bytes. "+
+			"Large pastes slow down applies and count towards the limits of free accounts. "+
+			"Consider splitting the content over multiple pastes, or raise large_paste_warn_bytes to silence this warning.",
+			plan.SizeBytes.ValueInt64(), threshold),
+	)
+	return diags
+}
+
+// checkAccountFeatures returns an error for each PRO feature that the paste
+// uses, but the account of the user does not support. The account type is
+// only requested if the paste uses such a feature.
+func (r *pasteResource) checkAccountFeatures(ctx context.Context, plan pasteResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	usesFolder := plan.Folder.ValueString() != ""
+	usesSize := plan.SizeBytes.ValueInt64() > maxPasteSize
+	if r.client == nil || (!usesFolder && !usesSize) {
+		return diags
+	}
+
+	accountType, err := r.client.AccountType(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to read the account type to check the features of the paste", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return diags
+	}
+
+	if usesFolder && accountType != "pro" {
+		diags.AddAttributeError(
+			path.Root("folder"),
+			"Pastebin Folder Requires PRO Account",
+			"The paste is created in folder "+plan.Folder.ValueString()+", but folders are only available for Pastebin PRO accounts "+
+				"and the provider is configured for a "+accountType+" account. Remove the folder from the paste.",
+		)
+	}
+	if usesSize {
+		var tooLargeErr *pasteTooLargeError
+		if err := r.client.checkPasteSize(ctx, int(plan.SizeBytes.ValueInt64())); errors.As(err, &tooLargeErr) {
+			diags.AddAttributeError(
+				plan.contentPath(),
+				"Pastebin Paste Too Large",
+				"The paste cannot be created, as its content is too large: "+tooLargeErr.Error()+". "+
+					"Split the content over multiple pastes, or use a Pastebin PRO account for pastes up to 10MB.",
+			)
+		}
+	}
+	return diags
+}
+
+// protectedError returns the error for deleting the protected paste with the
+// given key.
+func protectedError(pasteKey string) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("protected"),
+		"Protected Pastebin Paste",
+		"The paste "+pasteKey+" is protected and cannot be deleted. "+
+			"Set protected to false and apply the configuration before destroying or replacing the paste.",
+	)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *pasteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state pasteResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A protected paste must be unprotected before it can be deleted
+	if state.Protected.ValueBool() {
+		resp.Diagnostics.Append(protectedError(state.ID.ValueString()))
+		return
+	}
+
+	deleteTimeout, diags := operationTimeout(state.Timeouts, "delete", defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Pastebin only lets the owner of a paste delete it, so guest pastes
+	// remain until they expire
+	if r.client.client.UserKey == "" {
+		resp.Diagnostics.AddWarning(
+			"Guest Pastebin Paste Not Deleted",
+			"The paste "+state.ID.ValueString()+" was created as a guest and cannot be deleted through the Pastebin API. "+
+				"It is removed from the Terraform state, but remains available on Pastebin until it expires.",
+		)
+		return
+	}
+
+	// Delete existing paste, which is already done if the paste expired or
+	// was deleted outside of Terraform
+	err := r.client.DeletePaste(ctx, state.ID.ValueString())
+	if errors.Is(err, errPasteNotFound) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not delete paste %s: %w", state.ID.ValueString(), err)))
+		return
+	}
+}
+
+// ImportState imports an existing paste by its key.
+func (r *pasteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Terraform imports a single resource instance per import id
+	if strings.Contains(req.ID, ",") {
+		resp.Diagnostics.AddError(
+			"Multiple Pastebin Paste Keys in Import ID",
+			"The import id "+req.ID+" contains multiple paste keys, but a paste resource can only import a single paste. "+
+				"Import each paste separately, for example with an import block that uses for_each over the paste keys.",
+		)
+		return
+	}
+	if err := validatePasteKey(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Pastebin Paste Import ID",
+			"The import id must be the key of the paste, such as abcd1234 for https://pastebin.com/abcd1234, but the "+err.Error()+".",
+		)
+		return
+	}
+
+	// Ensure the paste is accessible before adopting it. Public and unlisted
+	// pastes of other users can only be read anonymously.
+	_, err := r.client.GetPaste(ctx, req.ID)
+	if errors.Is(err, errPasteNotFound) && r.client.client.UserKey != "" {
+		_, err = r.client.GetPublicPaste(ctx, req.ID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Pastebin Paste",
+			"Could not read Pastebin paste with key "+req.ID+". "+
+				"The paste may be private to another user, or it may have expired or been deleted.\n\n"+
+				"PasteBin Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	// Pastes are read in the default charset until another one is configured
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("content_charset"), defaultCharset)...)
+
+	// Pastebin only returns the metadata of a paste when listing the pastes of the user
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	if r.client.client.UserKey == "" {
+		return
+	}
+	paste, err := r.client.FindPaste(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Pastebin Paste",
+			"Could not list the pastes of the user to read the metadata of paste "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	// Let read refresh the content
+	if paste != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("title"), paste.Title)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("format"), paste.FormatShort)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("visibility"), pasteVisibility(paste.Private))...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("created_at"), unixTimestamp(paste.Date))...)
+	}
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithMoveState    = &pasteResource{}
+	_ resource.ResourceWithUpgradeState = &pasteResource{}
+)
+
+// rawPasteState is the raw JSON state of a paste resource of an earlier schema
+// version or another provider. Attributes that are missing fall back to their
+// defaults, and computed attributes are recomputed or refreshed by read.
+type rawPasteState struct {
+	ID                       string            `json:"id"`
+	Content                  *string           `json:"content"`
+	ContentBase64            *string           `json:"content_base64"`
+	ContentSensitive         *bool             `json:"content_sensitive"`
+	SourceFile               *string           `json:"source_file"`
+	ContentHash              *string           `json:"content_hash"`
+	Title                    *string           `json:"title"`
+	Expire                   *string           `json:"expire"`
+	Visibility               *string           `json:"visibility"`
+	Format                   *string           `json:"format"`
+	RecreateOnUpdate         *bool             `json:"recreate_on_update"`
+	MatchExistingByTitle     *bool             `json:"match_existing_by_title"`
+	CreateIfAbsent           *bool             `json:"create_if_absent"`
+	NormalizeLineEndings     *bool             `json:"normalize_line_endings"`
+	Folder                   *string           `json:"folder"`
+	Keepers                  map[string]string `json:"keepers"`
+	Protected                *bool             `json:"protected"`
+	GenerateTitleFromContent *bool             `json:"generate_title_from_content"`
+	TrimTrailingWhitespace   *bool             `json:"trim_trailing_whitespace"`
+	AutoDetectFormat         *bool             `json:"auto_detect_format"`
+	ContentCharset           *string           `json:"content_charset"`
+	CreatedAt                *string           `json:"created_at"`
+	ExpiresAt                *string           `json:"expires_at"`
+}
+
+// model returns the resource model of the raw state.
+func (s rawPasteState) model(client *pastebinClient) pasteResourceModel {
+	m := pasteResourceModel{
+		ID:                       types.StringValue(s.ID),
+		Content:                  types.StringPointerValue(s.Content),
+		ContentBase64:            types.StringPointerValue(s.ContentBase64),
+		ContentSensitive:         types.BoolValue(s.ContentSensitive != nil && *s.ContentSensitive),
+		SourceFile:               types.StringPointerValue(s.SourceFile),
+		ContentHash:              types.StringPointerValue(s.ContentHash),
+		ContentSha256:            types.StringNull(),
+		SizeBytes:                types.Int64Null(),
+		Title:                    types.StringPointerValue(s.Title),
+		Expire:                   types.StringPointerValue(s.Expire),
+		Visibility:               types.StringValue("unlisted"),
+		Format:                   types.StringPointerValue(s.Format),
+		RecreateOnUpdate:         types.BoolValue(s.RecreateOnUpdate != nil && *s.RecreateOnUpdate),
+		MatchExistingByTitle:     types.BoolValue(s.MatchExistingByTitle != nil && *s.MatchExistingByTitle),
+		CreateIfAbsent:           types.BoolValue(s.CreateIfAbsent != nil && *s.CreateIfAbsent),
+		NormalizeLineEndings:     types.BoolValue(s.NormalizeLineEndings != nil && *s.NormalizeLineEndings),
+		Folder:                   types.StringPointerValue(s.Folder),
+		Keepers:                  types.MapNull(types.StringType),
+		Protected:                types.BoolValue(s.Protected != nil && *s.Protected),
+		GenerateTitleFromContent: types.BoolValue(s.GenerateTitleFromContent != nil && *s.GenerateTitleFromContent),
+		TrimTrailingWhitespace:   types.BoolValue(s.TrimTrailingWhitespace != nil && *s.TrimTrailingWhitespace),
+		AutoDetectFormat:         types.BoolValue(s.AutoDetectFormat != nil && *s.AutoDetectFormat),
+		ContentCharset:           types.StringValue(defaultCharset),
+		Url:                      types.StringNull(),
+		RawUrl:                   types.StringNull(),
+		CreatedAt:                types.StringPointerValue(s.CreatedAt),
+		ExpiresAt:                types.StringPointerValue(s.ExpiresAt),
+		Timeouts:                 timeoutsNull(),
+	}
+	if s.Visibility != nil {
+		m.Visibility = types.StringValue(*s.Visibility)
+	}
+	if s.ContentCharset != nil {
+		m.ContentCharset = types.StringValue(*s.ContentCharset)
+	}
+	if s.Keepers != nil {
+		keepers := map[string]attr.Value{}
+		for key, value := range s.Keepers {
+			keepers[key] = types.StringValue(value)
+		}
+		m.Keepers = types.MapValueMust(types.StringType, keepers)
+	}
+
+	// Recompute the hash and size of the stored content, read refreshes them
+	// otherwise
+	if !m.ContentHash.IsNull() {
+		m.ContentSha256 = m.ContentHash
+	} else if !m.Content.IsNull() {
+		content := m.normalize(m.Content.ValueString())
+		m.ContentSha256 = types.StringValue(contentHash(content))
+		m.SizeBytes = types.Int64Value(int64(len(content)))
+	}
+	if client != nil {
+		m.Url = types.StringValue(client.PasteUrl(s.ID))
+		m.RawUrl = types.StringValue(client.RawPasteUrl(s.ID))
+	}
+	return m
+}
+
+// UpgradeState returns the state upgraders from the earlier schema versions.
+func (r *pasteResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 has no content_sha256, url and raw_url attributes, and may
+		// miss any of the attributes that were added later.
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var source rawPasteState
+				if req.RawState == nil || json.Unmarshal(req.RawState.JSON, &source) != nil || source.ID == "" {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Pastebin Paste State",
+						"The version 0 state of the paste does not contain a paste id. "+
+							"Remove the paste from the state and import it again.",
+					)
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, source.model(r.client))...)
+			},
+		},
+	}
+}
+
+// MoveState returns the state movers that move the state of a paste resource
+// from another Pastebin provider, such as a fork of this provider.
+func (r *pasteResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				// Skip anything but a paste resource of a Pastebin provider
+				if req.SourceTypeName != "pastebin_paste" || !strings.HasSuffix(req.SourceProviderAddress, "/pastebin") {
+					return
+				}
+				if req.SourceRawState == nil || req.SourceRawState.JSON == nil {
+					resp.Diagnostics.AddError(
+						"Unable to Move Pastebin Paste State",
+						"The state of the "+req.SourceProviderAddress+" pastebin_paste resource is empty or not stored as JSON. "+
+							"Please report this issue to the provider developers.",
+					)
+					return
+				}
+
+				var source rawPasteState
+				if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil || source.ID == "" {
+					resp.Diagnostics.AddError(
+						"Unable to Move Pastebin Paste State",
+						"The state of the "+req.SourceProviderAddress+" pastebin_paste resource does not contain a paste id. "+
+							"Import the paste instead of moving it.",
+					)
+					return
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, source.model(r.client))...)
+			},
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPasteResourceUpgradeStateV0(t *testing.T) {
+	attributes, diagnostics := testUpgradeResourceState(t, "pastebin_paste", 0, `{"id":"abcd1234","content":"hello","title":"greeting"}`)
+	if testHasError(diagnostics) {
+		t.Fatal(diagnostics)
+	}
+
+	expected := map[string]tftypes.Value{
+		"id":                 tftypes.NewValue(tftypes.String, "abcd1234"),
+		"content":            tftypes.NewValue(tftypes.String, "hello"),
+		"content_sha256":     tftypes.NewValue(tftypes.String, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+		"size_bytes":         tftypes.NewValue(tftypes.Number, 5),
+		"title":              tftypes.NewValue(tftypes.String, "greeting"),
+		"visibility":         tftypes.NewValue(tftypes.String, "unlisted"),
+		"recreate_on_update": tftypes.NewValue(tftypes.Bool, false),
+		"format":             tftypes.NewValue(tftypes.String, nil),
+	}
+	for name, value := range expected {
+		if !attributes[name].Equal(value) {
+			t.Errorf("expected %s to be %s, got %s", name, value, attributes[name])
+		}
+	}
+
+	_, diagnostics = testUpgradeResourceState(t, "pastebin_paste", 0, `{"content":"hello"}`)
+	if !testHasError(diagnostics) {
+		t.Error("expected an error for a state without id")
+	}
+}
+
+func TestPasteResourceMoveState(t *testing.T) {
+	testCases := map[string]struct {
+		sourceProviderAddress string
+		sourceTypeName        string
+		sourceState           string
+		expected              map[string]tftypes.Value
+		expectErr             bool
+	}{
+		"paste of another provider": {
+			sourceProviderAddress: "registry.terraform.io/example/pastebin",
+			sourceTypeName:        "pastebin_paste",
+			sourceState:           `{"id":"abcd1234","content":"Hello from Terraform.","title":"greeting","unknown":true}`,
+			expected: map[string]tftypes.Value{
+				"id":         tftypes.NewValue(tftypes.String, "abcd1234"),
+				"content":    tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"title":      tftypes.NewValue(tftypes.String, "greeting"),
+				"visibility": tftypes.NewValue(tftypes.String, "unlisted"),
+				"expire":     tftypes.NewValue(tftypes.String, nil),
+			},
+		},
+		"paste without id": {
+			sourceProviderAddress: "registry.terraform.io/example/pastebin",
+			sourceTypeName:        "pastebin_paste",
+			sourceState:           `{"content":"Hello from Terraform."}`,
+			expectErr:             true,
+		},
+		"other resource": {
+			sourceProviderAddress: "registry.terraform.io/hashicorp/local",
+			sourceTypeName:        "local_file",
+			sourceState:           `{"id":"abcd1234","content":"Hello from Terraform."}`,
+			expectErr:             true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes, diagnostics := testMoveResourceState(t, testCase.sourceProviderAddress, testCase.sourceTypeName, testCase.sourceState, "pastebin_paste")
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Fatalf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+			for name, expected := range testCase.expected {
+				if !attributes[name].Equal(expected) {
+					t.Errorf("expected %s to be %s, got %s", name, expected, attributes[name])
+				}
+			}
+		})
+	}
+}
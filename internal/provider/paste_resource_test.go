@@ -0,0 +1,1705 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"terraform-provider-pastebin/internal/pastebintest"
+)
+
+// testTimeouts returns a timeouts block with the create and delete timeouts.
+func testTimeouts(create, delete string) tftypes.Value {
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"create": tftypes.String,
+		"read":   tftypes.String,
+		"update": tftypes.String,
+		"delete": tftypes.String,
+	}}
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"create": tftypes.NewValue(tftypes.String, create),
+		"read":   tftypes.NewValue(tftypes.String, nil),
+		"update": tftypes.NewValue(tftypes.String, nil),
+		"delete": tftypes.NewValue(tftypes.String, delete),
+	})
+}
+
+func TestPasteResourceValidateConfig(t *testing.T) {
+	testCases := map[string]struct {
+		config    map[string]tftypes.Value
+		expectErr bool
+	}{
+		"valid": {
+			config: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+			},
+		},
+		"empty content": {
+			config: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, ""),
+			},
+			expectErr: true,
+		},
+		"whitespace content": {
+			config: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, " \n\t"),
+			},
+			expectErr: true,
+		},
+		"source file": {
+			config: map[string]tftypes.Value{
+				"source_file": tftypes.NewValue(tftypes.String, "hello.txt"),
+			},
+		},
+		"content and source file": {
+			config: map[string]tftypes.Value{
+				"content":     tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"source_file": tftypes.NewValue(tftypes.String, "hello.txt"),
+			},
+			expectErr: true,
+		},
+		"content base64": {
+			config: map[string]tftypes.Value{
+				"content_base64": tftypes.NewValue(tftypes.String, "SGVsbG8gZnJvbSBUZXJyYWZvcm0u"),
+			},
+		},
+		"invalid content base64": {
+			config: map[string]tftypes.Value{
+				"content_base64": tftypes.NewValue(tftypes.String, "SGVsbG8*"),
+			},
+			expectErr: true,
+		},
+		"content and content base64": {
+			config: map[string]tftypes.Value{
+				"content":        tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"content_base64": tftypes.NewValue(tftypes.String, "SGVsbG8gZnJvbSBUZXJyYWZvcm0u"),
+			},
+			expectErr: true,
+		},
+		"no content": {
+			config:    map[string]tftypes.Value{},
+			expectErr: true,
+		},
+		"invalid expire": {
+			config: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"expire":  tftypes.NewValue(tftypes.String, "2D"),
+			},
+			expectErr: true,
+		},
+		"timeouts": {
+			config: map[string]tftypes.Value{
+				"content":  tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"timeouts": testTimeouts("10m", "30s"),
+			},
+		},
+		"invalid timeouts": {
+			config: map[string]tftypes.Value{
+				"content":  tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"timeouts": testTimeouts("ten minutes", "-30s"),
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diagnostics := testValidateResourceConfig(t, "pastebin_paste", testCase.config)
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+		})
+	}
+}
+
+func TestPasteResourceValidateConfigWarnings(t *testing.T) {
+	t.Setenv("PASTEBIN_USER_KEY", "")
+	unknownString := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	sourceFile := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(sourceFile, []byte("package main"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		config   map[string]tftypes.Value
+		expected string
+	}{
+		"title from unknown content": {
+			config: map[string]tftypes.Value{
+				"content":                     unknownString,
+				"generate_title_from_content": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: "Pastebin Paste Title Unknown Until Apply",
+		},
+		"title from known content": {
+			config: map[string]tftypes.Value{
+				"content":                     tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"generate_title_from_content": tftypes.NewValue(tftypes.Bool, true),
+			},
+		},
+		"configured title": {
+			config: map[string]tftypes.Value{
+				"content":                     unknownString,
+				"title":                       tftypes.NewValue(tftypes.String, "Notes"),
+				"generate_title_from_content": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: "Pastebin Paste Title Not Generated",
+		},
+		"format from unknown source file": {
+			config: map[string]tftypes.Value{
+				"source_file":        unknownString,
+				"auto_detect_format": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: "Pastebin Paste Format Unknown Until Apply",
+		},
+		"format from known source file": {
+			config: map[string]tftypes.Value{
+				"source_file":        tftypes.NewValue(tftypes.String, sourceFile),
+				"auto_detect_format": tftypes.NewValue(tftypes.Bool, true),
+			},
+		},
+		"format without source file": {
+			config: map[string]tftypes.Value{
+				"content":            tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"auto_detect_format": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: "Pastebin Paste Format Not Detected",
+		},
+		"unknown visibility": {
+			config: map[string]tftypes.Value{
+				"content":    tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"visibility": unknownString,
+			},
+			expected: "Pastebin Paste Visibility Unknown Until Apply",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diagnostics := testPlanResourceCreate(t, map[string]tftypes.Value{
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			}, "pastebin_paste", testCase.config)
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			var warnings []string
+			for _, diagnostic := range diagnostics {
+				if diagnostic.Severity == tfprotov6.DiagnosticSeverityWarning {
+					warnings = append(warnings, diagnostic.Summary)
+				}
+			}
+			if testCase.expected == "" && len(warnings) != 0 {
+				t.Errorf("expected no warnings, got %v", warnings)
+			}
+			if testCase.expected != "" && (len(warnings) != 1 || warnings[0] != testCase.expected) {
+				t.Errorf("expected warning %q, got %v", testCase.expected, warnings)
+			}
+		})
+	}
+}
+
+func TestPasteResourceModelContent(t *testing.T) {
+	sourceFile := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(sourceFile, []byte("Hello from a file."), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	content, diags := pasteResourceModel{
+		Content:    types.StringNull(),
+		SourceFile: types.StringValue(sourceFile),
+	}.content()
+	if diags.HasError() || content != "Hello from a file." {
+		t.Errorf("expected the content of the source file, got %q (%v)", content, diags)
+	}
+
+	_, diags = pasteResourceModel{
+		Content:    types.StringNull(),
+		SourceFile: types.StringValue(filepath.Join(t.TempDir(), "missing.txt")),
+	}.content()
+	if !diags.HasError() {
+		t.Error("expected an error for a missing source file")
+	}
+
+	content, diags = pasteResourceModel{
+		Content:              types.StringNull(),
+		ContentBase64:        types.StringValue("SGVsbG8NCmZyb20gYmFzZTY0Lg=="),
+		SourceFile:           types.StringNull(),
+		NormalizeLineEndings: types.BoolValue(true),
+	}.content()
+	if diags.HasError() || content != "Hello\r\nfrom base64." {
+		t.Errorf("expected the decoded content as is, got %q (%v)", content, diags)
+	}
+
+	_, diags = pasteResourceModel{
+		Content:       types.StringNull(),
+		ContentBase64: types.StringValue("SGVsbG8*"),
+		SourceFile:    types.StringNull(),
+	}.content()
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "at byte 7") {
+		t.Errorf("expected an error for the invalid base64 byte, got %v", diags)
+	}
+}
+
+func TestPasteResourceModelNormalizeLineEndings(t *testing.T) {
+	sourceFile := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(sourceFile, []byte("Hello\r\nfrom\na\rfile.\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		model    pasteResourceModel
+		expected string
+	}{
+		"content": {
+			model: pasteResourceModel{
+				Content:              types.StringValue("Hello\r\nfrom\nTerraform.\r\n"),
+				SourceFile:           types.StringNull(),
+				NormalizeLineEndings: types.BoolValue(true),
+			},
+			expected: "Hello\nfrom\nTerraform.\n",
+		},
+		"source file": {
+			model: pasteResourceModel{
+				Content:              types.StringNull(),
+				SourceFile:           types.StringValue(sourceFile),
+				NormalizeLineEndings: types.BoolValue(true),
+			},
+			expected: "Hello\nfrom\na\nfile.\n",
+		},
+		"disabled": {
+			model: pasteResourceModel{
+				Content:              types.StringValue("Hello\r\nfrom\nTerraform.\r\n"),
+				SourceFile:           types.StringNull(),
+				NormalizeLineEndings: types.BoolValue(false),
+			},
+			expected: "Hello\r\nfrom\nTerraform.\r\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			content, diags := testCase.model.content()
+			if diags.HasError() || content != testCase.expected {
+				t.Errorf("expected content %q, got %q (%v)", testCase.expected, content, diags)
+			}
+		})
+	}
+}
+
+func TestPasteResourceModelChanged(t *testing.T) {
+	state := pasteResourceModel{
+		Content:     types.StringValue("Hello from Terraform."),
+		ContentHash: types.StringNull(),
+		Expire:      types.StringValue("1D"),
+		Visibility:  types.StringValue("unlisted"),
+		Format:      types.StringValue("text"),
+		Title:       types.StringValue("greeting"),
+	}
+
+	testCases := map[string]struct {
+		modify   func(plan *pasteResourceModel)
+		expected bool
+	}{
+		"unchanged": {
+			modify: func(_ *pasteResourceModel) {},
+		},
+		"content": {
+			modify:   func(plan *pasteResourceModel) { plan.Content = types.StringValue("Hello again.") },
+			expected: true,
+		},
+		"expire": {
+			modify:   func(plan *pasteResourceModel) { plan.Expire = types.StringValue("1W") },
+			expected: true,
+		},
+		"visibility": {
+			modify:   func(plan *pasteResourceModel) { plan.Visibility = types.StringValue("public") },
+			expected: true,
+		},
+		"format": {
+			modify:   func(plan *pasteResourceModel) { plan.Format = types.StringValue("go") },
+			expected: true,
+		},
+		"title": {
+			modify: func(plan *pasteResourceModel) { plan.Title = types.StringValue("welcome") },
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			plan := state
+			testCase.modify(&plan)
+			if changed := plan.changed(state); changed != testCase.expected {
+				t.Errorf("expected changed %t, got %t", testCase.expected, changed)
+			}
+		})
+	}
+}
+
+func TestPasteResourcePlanPrivatePaste(t *testing.T) {
+	t.Setenv("PASTEBIN_USER_KEY", "")
+
+	testCases := map[string]struct {
+		userKey           string
+		defaultVisibility string
+		visibility        string
+		expectErr         bool
+	}{
+		"private": {
+			userKey:    "user",
+			visibility: "private",
+		},
+		"private guest": {
+			visibility: "private",
+			expectErr:  true,
+		},
+		"unlisted guest": {
+			visibility: "unlisted",
+		},
+		"default guest": {},
+		"private default guest": {
+			defaultVisibility: "private",
+			expectErr:         true,
+		},
+		"private default with unlisted guest": {
+			defaultVisibility: "private",
+			visibility:        "unlisted",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			providerConfig := map[string]tftypes.Value{
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			}
+			if testCase.userKey != "" {
+				providerConfig["user_key"] = tftypes.NewValue(tftypes.String, testCase.userKey)
+			}
+			if testCase.defaultVisibility != "" {
+				providerConfig["default_visibility"] = tftypes.NewValue(tftypes.String, testCase.defaultVisibility)
+			}
+			config := map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+			}
+			if testCase.visibility != "" {
+				config["visibility"] = tftypes.NewValue(tftypes.String, testCase.visibility)
+			}
+			diagnostics := testPlanResourceCreate(t, providerConfig, "pastebin_paste", config)
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+			if testCase.expectErr && (len(diagnostics) != 1 || diagnostics[0].Summary != "Missing PasteBin API User Key") {
+				t.Errorf("expected a single missing user key error, got %v", diagnostics)
+			}
+		})
+	}
+}
+
+func TestPasteResourcePlanAccountFeatures(t *testing.T) {
+	testCases := map[string]struct {
+		accountType string
+		config      map[string]tftypes.Value
+		expectErr   bool
+	}{
+		"folder": {
+			accountType: "1",
+			config: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"folder":  tftypes.NewValue(tftypes.String, "configs"),
+			},
+		},
+		"folder normal account": {
+			accountType: "0",
+			config: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"folder":  tftypes.NewValue(tftypes.String, "configs"),
+			},
+			expectErr: true,
+		},
+		"large": {
+			accountType: "1",
+			config: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, strings.Repeat("a", maxPasteSize+1)),
+			},
+		},
+		"large normal account": {
+			accountType: "0",
+			config: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, strings.Repeat("a", maxPasteSize+1)),
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.FormValue("api_option") != "userdetails" {
+					t.Errorf("unexpected request %v", r.PostForm)
+				}
+				_, _ = w.Write([]byte("<user><user_account_type>" + testCase.accountType + "</user_account_type></user>"))
+			}))
+			defer server.Close()
+
+			diagnostics := testPlanResourceCreate(t, map[string]tftypes.Value{
+				"host":     tftypes.NewValue(tftypes.String, server.URL),
+				"dev_key":  tftypes.NewValue(tftypes.String, "dev"),
+				"user_key": tftypes.NewValue(tftypes.String, "user"),
+			}, "pastebin_paste", testCase.config)
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+			if requests != 1 {
+				t.Errorf("expected the account type to be requested once, got %d requests", requests)
+			}
+		})
+	}
+}
+
+func TestPasteResourcePlanSkipApiOnPlan(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte("<user><user_account_type>0</user_account_type></user>"))
+	}))
+	defer server.Close()
+
+	diagnostics := testPlanResourceCreate(t, map[string]tftypes.Value{
+		"host":             tftypes.NewValue(tftypes.String, server.URL),
+		"dev_key":          tftypes.NewValue(tftypes.String, "dev"),
+		"user_key":         tftypes.NewValue(tftypes.String, "user"),
+		"skip_api_on_plan": tftypes.NewValue(tftypes.Bool, true),
+	}, "pastebin_paste", map[string]tftypes.Value{
+		"content": tftypes.NewValue(tftypes.String, strings.Repeat("a", maxPasteSize+1)),
+		"folder":  tftypes.NewValue(tftypes.String, "configs"),
+	})
+	if testHasError(diagnostics) {
+		t.Errorf("unexpected error %v", diagnostics)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests during the plan, got %d requests", requests)
+	}
+}
+
+func TestPasteResourcePlanLargeContent(t *testing.T) {
+	large := strings.Repeat("a", 300*1024)
+	testCases := map[string]struct {
+		warnBytes tftypes.Value
+		state     map[string]tftypes.Value
+		content   string
+		expected  bool
+	}{
+		"default threshold": {
+			warnBytes: tftypes.NewValue(tftypes.Number, nil),
+			content:   large,
+			expected:  true,
+		},
+		"below default threshold": {
+			warnBytes: tftypes.NewValue(tftypes.Number, nil),
+			content:   strings.Repeat("a", 100*1024),
+		},
+		"configured threshold": {
+			warnBytes: tftypes.NewValue(tftypes.Number, 1024),
+			content:   strings.Repeat("a", 2048),
+			expected:  true,
+		},
+		"disabled": {
+			warnBytes: tftypes.NewValue(tftypes.Number, 0),
+			content:   large,
+		},
+		"unchanged content": {
+			warnBytes: tftypes.NewValue(tftypes.Number, nil),
+			state: map[string]tftypes.Value{
+				"id":                     tftypes.NewValue(tftypes.String, "abcd1234"),
+				"content":                tftypes.NewValue(tftypes.String, large),
+				"content_sha256":         tftypes.NewValue(tftypes.String, contentHash(large)),
+				"content_sensitive":      tftypes.NewValue(tftypes.Bool, false),
+				"expire":                 tftypes.NewValue(tftypes.String, "N"),
+				"visibility":             tftypes.NewValue(tftypes.String, "unlisted"),
+				"format":                 tftypes.NewValue(tftypes.String, "text"),
+				"normalize_line_endings": tftypes.NewValue(tftypes.Bool, false),
+			},
+			content: large,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			providerConfig := map[string]tftypes.Value{
+				"dev_key":                tftypes.NewValue(tftypes.String, "dev"),
+				"large_paste_warn_bytes": testCase.warnBytes,
+			}
+			config := map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, testCase.content),
+			}
+			var diagnostics []*tfprotov6.Diagnostic
+			if testCase.state == nil {
+				diagnostics = testPlanResourceCreate(t, providerConfig, "pastebin_paste", config)
+			} else {
+				_, diagnostics = testPlanResourceUpdate(t, providerConfig, "pastebin_paste", testCase.state, config)
+			}
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			warned := false
+			for _, diagnostic := range diagnostics {
+				if diagnostic.Severity == tfprotov6.DiagnosticSeverityWarning && diagnostic.Summary == "Large Pastebin Paste" {
+					warned = true
+				}
+			}
+			if warned != testCase.expected {
+				t.Errorf("expected warning %t, got %v", testCase.expected, diagnostics)
+			}
+		})
+	}
+}
+
+// testKeepers returns a keepers map with the given values.
+func testKeepers(keepers map[string]string) tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for key, value := range keepers {
+		values[key] = tftypes.NewValue(tftypes.String, value)
+	}
+	return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, values)
+}
+
+func TestPasteResourcePlanKeepers(t *testing.T) {
+	providerConfig := map[string]tftypes.Value{
+		"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+	}
+	state := map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, "abcd1234"),
+		"content":                tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+		"content_sensitive":      tftypes.NewValue(tftypes.Bool, false),
+		"visibility":             tftypes.NewValue(tftypes.String, "unlisted"),
+		"normalize_line_endings": tftypes.NewValue(tftypes.Bool, false),
+		"recreate_on_update":     tftypes.NewValue(tftypes.Bool, false),
+		"keepers":                testKeepers(map[string]string{"config_hash": "1"}),
+	}
+
+	testCases := map[string]struct {
+		keepers       tftypes.Value
+		expectReplace bool
+	}{
+		"unchanged": {keepers: testKeepers(map[string]string{"config_hash": "1"})},
+		"changed":   {keepers: testKeepers(map[string]string{"config_hash": "2"}), expectReplace: true},
+		"added":     {keepers: testKeepers(map[string]string{"config_hash": "1", "version": "2"}), expectReplace: true},
+		"removed":   {keepers: tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil), expectReplace: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"keepers": testCase.keepers,
+			}
+			requiresReplace, diagnostics := testPlanResourceUpdate(t, providerConfig, "pastebin_paste", state, config)
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			replaced := false
+			for _, attributePath := range requiresReplace {
+				if attributePath.Equal(tftypes.NewAttributePath().WithAttributeName("keepers")) {
+					replaced = true
+				}
+			}
+			if replaced != testCase.expectReplace {
+				t.Errorf("expected replace %t, got %v", testCase.expectReplace, requiresReplace)
+			}
+		})
+	}
+}
+
+func TestPasteResourceLifecycle(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	providerConfig := testFakeProviderConfig(server)
+
+	state, diagnostics := testApplyResourceCreate(t, providerConfig, "pastebin_paste", map[string]tftypes.Value{
+		"title":      tftypes.NewValue(tftypes.String, "Hello, World!"),
+		"content":    tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+		"visibility": tftypes.NewValue(tftypes.String, "private"),
+	})
+	if testHasError(diagnostics) {
+		t.Fatalf("unexpected create error %v", diagnostics)
+	}
+	var id, url string
+	if err := state["id"].As(&id); err != nil {
+		t.Fatal(err)
+	}
+	if err := state["url"].As(&url); err != nil {
+		t.Fatal(err)
+	}
+	paste, ok := server.Paste(id)
+	if !ok || paste.Content != "Hello from Terraform." || paste.Title != "Hello, World!" || paste.Private != "2" {
+		t.Fatalf("expected the paste to be created, got %+v", paste)
+	}
+	if url != server.URL+"/"+id {
+		t.Errorf("unexpected url %q", url)
+	}
+
+	// Read refreshes the content that changed outside of Terraform
+	server.AddPaste(pastebintest.Paste{Key: id, Content: "Changed outside of Terraform.", Private: "2", Owner: server.UserKey})
+	refreshed, diagnostics := testReadResource(t, providerConfig, "pastebin_paste", state)
+	if testHasError(diagnostics) || refreshed == nil {
+		t.Fatalf("unexpected read error %v", diagnostics)
+	}
+	if !refreshed["content"].Equal(tftypes.NewValue(tftypes.String, "Changed outside of Terraform.")) {
+		t.Errorf("expected the refreshed content, got %v", refreshed["content"])
+	}
+
+	diagnostics = testDestroyResource(t, providerConfig, "pastebin_paste", refreshed)
+	if testHasError(diagnostics) {
+		t.Fatalf("unexpected destroy error %v", diagnostics)
+	}
+	if _, ok := server.Paste(id); ok {
+		t.Error("expected the paste to be deleted")
+	}
+
+	// Read removes a paste that is deleted outside of Terraform
+	refreshed, diagnostics = testReadResource(t, providerConfig, "pastebin_paste", state)
+	if testHasError(diagnostics) || refreshed != nil {
+		t.Errorf("expected the deleted paste to be removed, got %v (%v)", refreshed, diagnostics)
+	}
+}
+
+func TestPasteResourceCreateError(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	server.InjectError("paste", "Bad API request, maximum number of 25 unlisted pastes for your free account")
+
+	_, diagnostics := testApplyResourceCreate(t, testFakeProviderConfig(server), "pastebin_paste", map[string]tftypes.Value{
+		"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+	})
+	if !testHasError(diagnostics) || diagnostics[0].Summary != "PasteBin API Rate Limit Error" {
+		t.Errorf("expected a rate limit error, got %v", diagnostics)
+	}
+	if server.Requests("paste") != 1 {
+		t.Errorf("expected a single create request, got %d", server.Requests("paste"))
+	}
+}
+
+func TestPasteResourceDestroyDeletedPaste(t *testing.T) {
+	testCases := map[string]struct {
+		body      string
+		expectErr bool
+	}{
+		"deleted": {
+			body: "Paste Removed",
+		},
+		"already deleted": {
+			body: "Bad API request, invalid permission to remove paste",
+		},
+		"invalid user key": {
+			body:      "Bad API request, invalid api_user_key",
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.FormValue("api_option") != "delete" || r.FormValue("api_paste_key") != "abcd1234" {
+					t.Errorf("unexpected request %v", r.PostForm)
+				}
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			diagnostics := testDestroyResource(t, map[string]tftypes.Value{
+				"host":        tftypes.NewValue(tftypes.String, server.URL),
+				"dev_key":     tftypes.NewValue(tftypes.String, "dev"),
+				"user_key":    tftypes.NewValue(tftypes.String, "user"),
+				"max_retries": tftypes.NewValue(tftypes.Number, 0),
+			}, "pastebin_paste", map[string]tftypes.Value{
+				"id":      tftypes.NewValue(tftypes.String, "abcd1234"),
+				"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+			})
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+		})
+	}
+}
+
+func TestPasteResourceDestroyProtected(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	providerConfig := testFakeProviderConfig(server)
+	id := server.AddPaste(pastebintest.Paste{Content: "Hello from Terraform.", Owner: server.UserKey})
+	state := map[string]tftypes.Value{
+		"id":        tftypes.NewValue(tftypes.String, id),
+		"content":   tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+		"protected": tftypes.NewValue(tftypes.Bool, true),
+	}
+
+	diagnostics := testDestroyResource(t, providerConfig, "pastebin_paste", state)
+	if !testHasError(diagnostics) || diagnostics[0].Summary != "Protected Pastebin Paste" {
+		t.Errorf("expected a protected paste error, got %v", diagnostics)
+	}
+	if _, ok := server.Paste(id); !ok || server.Requests("delete") != 0 {
+		t.Fatal("expected the protected paste not to be deleted")
+	}
+
+	state["protected"] = tftypes.NewValue(tftypes.Bool, false)
+	diagnostics = testDestroyResource(t, providerConfig, "pastebin_paste", state)
+	if testHasError(diagnostics) {
+		t.Fatalf("unexpected destroy error %v", diagnostics)
+	}
+	if _, ok := server.Paste(id); ok {
+		t.Error("expected the unprotected paste to be deleted")
+	}
+}
+
+func TestPasteResourceUpdateRecreateProtected(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	providerConfig := testFakeProviderConfig(server)
+	config := map[string]tftypes.Value{
+		"content":            tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+		"recreate_on_update": tftypes.NewValue(tftypes.Bool, true),
+		"protected":          tftypes.NewValue(tftypes.Bool, true),
+	}
+	state, diagnostics := testApplyResourceCreate(t, providerConfig, "pastebin_paste", config)
+	if testHasError(diagnostics) {
+		t.Fatalf("unexpected create error %v", diagnostics)
+	}
+	var id string
+	if err := state["id"].As(&id); err != nil {
+		t.Fatal(err)
+	}
+
+	config["content"] = tftypes.NewValue(tftypes.String, "Hello again from Terraform.")
+	_, diagnostics = testApplyResourceUpdate(t, providerConfig, "pastebin_paste", state, config)
+	if !testHasError(diagnostics) || diagnostics[0].Summary != "Protected Pastebin Paste" {
+		t.Errorf("expected a protected paste error, got %v", diagnostics)
+	}
+	if _, ok := server.Paste(id); !ok || server.Requests("delete") != 0 {
+		t.Error("expected the protected paste not to be deleted")
+	}
+	if server.Requests("paste") != 1 {
+		t.Errorf("expected no paste to be recreated, got %d created pastes", server.Requests("paste"))
+	}
+}
+
+func TestPasteResourceImportState(t *testing.T) {
+	testCases := map[string]struct {
+		id        string
+		expectErr bool
+	}{
+		"valid":              {id: "abcd1234"},
+		"too short":          {id: "abcd123", expectErr: true},
+		"too long":           {id: "abcd12345", expectErr: true},
+		"invalid characters": {id: "abcd-123", expectErr: true},
+		"url":                {id: "https://pastebin.com/abcd1234", expectErr: true},
+		"multiple keys":      {id: "abcd1234,efgh5678", expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/raw/abcd1234" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				_, _ = w.Write([]byte("Hello from Terraform."))
+			}))
+			defer server.Close()
+
+			_, diagnostics := testImportResourceState(t, map[string]tftypes.Value{
+				"host":    tftypes.NewValue(tftypes.String, server.URL),
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			}, "pastebin_paste", testCase.id)
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+			if testCase.expectErr && requests != 0 {
+				t.Errorf("expected no request for an invalid import id, got %d requests", requests)
+			}
+		})
+	}
+}
+
+func TestPasteResourcePlanAfterImport(t *testing.T) {
+	for name, userKey := range map[string]string{"guest": "", "user": "user"} {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			id := server.AddPaste(pastebintest.Paste{Content: "Hello from Terraform.", Private: "0", Owner: "user"})
+			providerConfig := testFakeProviderConfig(server)
+			if userKey == "" {
+				delete(providerConfig, "user_key")
+			}
+
+			imported, diagnostics := testImportResourceState(t, providerConfig, "pastebin_paste", id)
+			if testHasError(diagnostics) || imported == nil {
+				t.Fatalf("unexpected import error %v", diagnostics)
+			}
+			refreshed, diagnostics := testReadResource(t, providerConfig, "pastebin_paste", imported)
+			if testHasError(diagnostics) || refreshed == nil {
+				t.Fatalf("unexpected read error %v", diagnostics)
+			}
+
+			requiresReplace, diagnostics := testPlanResourceUpdate(t, providerConfig, "pastebin_paste", refreshed, map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+			})
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected plan error %v", diagnostics)
+			}
+			for _, attributePath := range requiresReplace {
+				if attributePath.Equal(tftypes.NewAttributePath().WithAttributeName("content_charset")) {
+					t.Errorf("expected the charset not to replace the imported paste, got %v", requiresReplace)
+				}
+			}
+		})
+	}
+}
+
+func TestPasteResourcePlanExpireAlias(t *testing.T) {
+	providerConfig := map[string]tftypes.Value{
+		"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+	}
+	state := map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, "abcd1234"),
+		"content":                tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+		"content_sensitive":      tftypes.NewValue(tftypes.Bool, false),
+		"expire":                 tftypes.NewValue(tftypes.String, "1W"),
+		"visibility":             tftypes.NewValue(tftypes.String, "unlisted"),
+		"normalize_line_endings": tftypes.NewValue(tftypes.Bool, false),
+		"recreate_on_update":     tftypes.NewValue(tftypes.Bool, false),
+	}
+
+	testCases := map[string]struct {
+		expire        string
+		expectReplace bool
+	}{
+		"unchanged": {expire: "1W"},
+		"alias":     {expire: "1 week"},
+		"changed":   {expire: "1 day", expectReplace: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"expire":  tftypes.NewValue(tftypes.String, testCase.expire),
+			}
+			requiresReplace, diagnostics := testPlanResourceUpdate(t, providerConfig, "pastebin_paste", state, config)
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			replaced := false
+			for _, attributePath := range requiresReplace {
+				if attributePath.Equal(tftypes.NewAttributePath().WithAttributeName("expire")) {
+					replaced = true
+				}
+			}
+			if replaced != testCase.expectReplace {
+				t.Errorf("expected replace %t, got %v", testCase.expectReplace, requiresReplace)
+			}
+		})
+	}
+}
+
+func TestPasteResourcePlanRecreateOnUpdate(t *testing.T) {
+	providerConfig := map[string]tftypes.Value{
+		"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+	}
+
+	testCases := map[string]struct {
+		attribute        string
+		value            string
+		recreateOnUpdate bool
+	}{
+		"format":                        {attribute: "format", value: "go"},
+		"format recreate on update":     {attribute: "format", value: "go", recreateOnUpdate: true},
+		"visibility":                    {attribute: "visibility", value: "public"},
+		"visibility recreate on update": {attribute: "visibility", value: "public", recreateOnUpdate: true},
+		"expire":                        {attribute: "expire", value: "1D"},
+		"expire recreate on update":     {attribute: "expire", value: "1D", recreateOnUpdate: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := map[string]tftypes.Value{
+				"id":                     tftypes.NewValue(tftypes.String, "abcd1234"),
+				"content":                tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"content_sensitive":      tftypes.NewValue(tftypes.Bool, false),
+				"expire":                 tftypes.NewValue(tftypes.String, "1W"),
+				"visibility":             tftypes.NewValue(tftypes.String, "unlisted"),
+				"format":                 tftypes.NewValue(tftypes.String, "text"),
+				"normalize_line_endings": tftypes.NewValue(tftypes.Bool, false),
+				"recreate_on_update":     tftypes.NewValue(tftypes.Bool, testCase.recreateOnUpdate),
+			}
+			config := map[string]tftypes.Value{
+				"content":            tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"recreate_on_update": tftypes.NewValue(tftypes.Bool, testCase.recreateOnUpdate),
+				testCase.attribute:   tftypes.NewValue(tftypes.String, testCase.value),
+			}
+			requiresReplace, diagnostics := testPlanResourceUpdate(t, providerConfig, "pastebin_paste", state, config)
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			replaced := false
+			for _, attributePath := range requiresReplace {
+				if attributePath.Equal(tftypes.NewAttributePath().WithAttributeName(testCase.attribute)) {
+					replaced = true
+				}
+			}
+			if replaced == testCase.recreateOnUpdate {
+				t.Errorf("expected replace %t, got %v", !testCase.recreateOnUpdate, requiresReplace)
+			}
+		})
+	}
+}
+
+func TestPasteResourceUpdateRecreatesFormat(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	providerConfig := testFakeProviderConfig(server)
+	config := map[string]tftypes.Value{
+		"title":              tftypes.NewValue(tftypes.String, "Hello, World!"),
+		"content":            tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+		"format":             tftypes.NewValue(tftypes.String, "text"),
+		"visibility":         tftypes.NewValue(tftypes.String, "private"),
+		"recreate_on_update": tftypes.NewValue(tftypes.Bool, true),
+	}
+	state, diagnostics := testApplyResourceCreate(t, providerConfig, "pastebin_paste", config)
+	if testHasError(diagnostics) {
+		t.Fatalf("unexpected create error %v", diagnostics)
+	}
+	var previous string
+	if err := state["id"].As(&previous); err != nil {
+		t.Fatal(err)
+	}
+
+	config["format"] = tftypes.NewValue(tftypes.String, "go")
+	updated, diagnostics := testApplyResourceUpdate(t, providerConfig, "pastebin_paste", state, config)
+	if testHasError(diagnostics) {
+		t.Fatalf("unexpected update error %v", diagnostics)
+	}
+	var id string
+	if err := updated["id"].As(&id); err != nil {
+		t.Fatal(err)
+	}
+	if id == previous {
+		t.Fatalf("expected the paste to be recreated with a new id, got %s", id)
+	}
+	paste, ok := server.Paste(id)
+	if !ok || paste.Format != "go" || paste.Title != "Hello, World!" || paste.Private != "2" || paste.Content != "Hello from Terraform." {
+		t.Errorf("expected the paste to be recreated with the new format and the other settings, got %+v", paste)
+	}
+	if _, ok := server.Paste(previous); ok {
+		t.Error("expected the previous paste to be deleted")
+	}
+}
+
+func TestPasteResourceReadOtherUser(t *testing.T) {
+	testCases := map[string]struct {
+		private string
+		removed bool
+	}{
+		"public":   {private: "0"},
+		"unlisted": {private: "1"},
+		"private":  {private: "2", removed: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			id := server.AddPaste(pastebintest.Paste{Content: "Hello from another user.", Private: testCase.private, Owner: "other"})
+
+			refreshed, diagnostics := testReadResource(t, testFakeProviderConfig(server), "pastebin_paste", map[string]tftypes.Value{
+				"id":      tftypes.NewValue(tftypes.String, id),
+				"content": tftypes.NewValue(tftypes.String, "Hello from another user."),
+			})
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected read error %v", diagnostics)
+			}
+			if removed := refreshed == nil; removed != testCase.removed {
+				t.Fatalf("expected removed %t, got state %v", testCase.removed, refreshed)
+			}
+			if !testCase.removed && !refreshed["content"].Equal(tftypes.NewValue(tftypes.String, "Hello from another user.")) {
+				t.Errorf("expected the content of the paste, got %v", refreshed["content"])
+			}
+		})
+	}
+}
+
+func TestPasteResourceImportOtherUser(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	id := server.AddPaste(pastebintest.Paste{Content: "Hello from another user.", Private: "0", Owner: "other"})
+
+	imported, diagnostics := testImportResourceState(t, testFakeProviderConfig(server), "pastebin_paste", id)
+	if testHasError(diagnostics) || imported == nil {
+		t.Fatalf("unexpected import error %v", diagnostics)
+	}
+	if !imported["id"].Equal(tftypes.NewValue(tftypes.String, id)) {
+		t.Errorf("expected id %s, got %v", id, imported["id"])
+	}
+}
+
+func TestPasteResourceReadError(t *testing.T) {
+	testCases := map[string]struct {
+		userKey     bool
+		inject      *pastebintest.Response
+		expectOwner bool
+	}{
+		"private paste without user key": {
+			expectOwner: true,
+		},
+		"server error": {
+			userKey: true,
+			inject:  &pastebintest.Response{StatusCode: http.StatusServiceUnavailable, Body: "unavailable"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			id := server.AddPaste(pastebintest.Paste{Content: "Hello from Terraform.", Private: "2", Owner: server.UserKey})
+			if testCase.inject != nil {
+				server.InjectResponse("show_paste", *testCase.inject)
+			}
+			providerConfig := testFakeProviderConfig(server)
+			if !testCase.userKey {
+				delete(providerConfig, "user_key")
+			}
+
+			_, diagnostics := testReadResource(t, providerConfig, "pastebin_paste", map[string]tftypes.Value{
+				"id":         tftypes.NewValue(tftypes.String, id),
+				"content":    tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"visibility": tftypes.NewValue(tftypes.String, "private"),
+			})
+			if !testHasError(diagnostics) {
+				t.Fatal("expected a read error")
+			}
+			detail := diagnostics[len(diagnostics)-1].Detail
+			if !strings.Contains(detail, "could not read paste "+id) {
+				t.Errorf("expected the paste in the error, got %q", detail)
+			}
+			if owner := strings.Contains(detail, "user_key of its owner"); owner != testCase.expectOwner {
+				t.Errorf("expected the owner hint %t, got %q", testCase.expectOwner, detail)
+			}
+		})
+	}
+}
+
+func TestPasteResourceReadTrailingWhitespace(t *testing.T) {
+	testCases := map[string]struct {
+		trim     bool
+		remote   string
+		expected string
+	}{
+		"trimmed by pastebin":        {trim: true, remote: "Hello from Terraform.", expected: "Hello from Terraform.\n\n"},
+		"trailing spaces":            {trim: true, remote: "Hello from Terraform. \t", expected: "Hello from Terraform.\n\n"},
+		"not trimmed":                {trim: false, remote: "Hello from Terraform.", expected: "Hello from Terraform."},
+		"changed content":            {trim: true, remote: "Hello from Pastebin.", expected: "Hello from Pastebin."},
+		"significant trailing lines": {trim: true, remote: "Hello from Terraform.\n\nGoodbye.", expected: "Hello from Terraform.\n\nGoodbye."},
+		"leading whitespace":         {trim: true, remote: "\nHello from Terraform.", expected: "\nHello from Terraform."},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			id := server.AddPaste(pastebintest.Paste{Content: testCase.remote, Private: "2", Owner: server.UserKey})
+
+			refreshed, diagnostics := testReadResource(t, testFakeProviderConfig(server), "pastebin_paste", map[string]tftypes.Value{
+				"id":                       tftypes.NewValue(tftypes.String, id),
+				"content":                  tftypes.NewValue(tftypes.String, "Hello from Terraform.\n\n"),
+				"visibility":               tftypes.NewValue(tftypes.String, "private"),
+				"trim_trailing_whitespace": tftypes.NewValue(tftypes.Bool, testCase.trim),
+			})
+			if testHasError(diagnostics) || refreshed == nil {
+				t.Fatalf("unexpected read error %v", diagnostics)
+			}
+			if !refreshed["content"].Equal(tftypes.NewValue(tftypes.String, testCase.expected)) {
+				t.Errorf("expected content %q, got %v", testCase.expected, refreshed["content"])
+			}
+		})
+	}
+}
+
+func TestPasteResourceReadThenPlanTrailingWhitespace(t *testing.T) {
+	configured := "Hello from Terraform.\n\n"
+	sourceFile := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(sourceFile, []byte(configured), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	testCases := map[string]map[string]tftypes.Value{
+		"content": {
+			"content": tftypes.NewValue(tftypes.String, configured),
+		},
+		"source file": {
+			"source_file":  tftypes.NewValue(tftypes.String, sourceFile),
+			"content_hash": tftypes.NewValue(tftypes.String, contentHash(configured)),
+		},
+	}
+
+	for name, attributes := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			id := server.AddPaste(pastebintest.Paste{Content: "Hello from Terraform.", Private: "2", Owner: server.UserKey})
+			providerConfig := testFakeProviderConfig(server)
+
+			state := map[string]tftypes.Value{
+				"id":                       tftypes.NewValue(tftypes.String, id),
+				"visibility":               tftypes.NewValue(tftypes.String, "private"),
+				"trim_trailing_whitespace": tftypes.NewValue(tftypes.Bool, true),
+				"content_sha256":           tftypes.NewValue(tftypes.String, contentHash(configured)),
+				"size_bytes":               tftypes.NewValue(tftypes.Number, len(configured)),
+			}
+			config := map[string]tftypes.Value{
+				"visibility":               tftypes.NewValue(tftypes.String, "private"),
+				"trim_trailing_whitespace": tftypes.NewValue(tftypes.Bool, true),
+			}
+			for name, value := range attributes {
+				state[name] = value
+				if name != "content_hash" {
+					config[name] = value
+				}
+			}
+
+			refreshed, diagnostics := testReadResource(t, providerConfig, "pastebin_paste", state)
+			if testHasError(diagnostics) || refreshed == nil {
+				t.Fatalf("unexpected read error %v", diagnostics)
+			}
+			// The plan derives the hash and size from the configured content,
+			// so the refreshed state must keep them to avoid a permanent diff
+			for _, name := range []string{"content_hash", "content_sha256", "size_bytes"} {
+				if expected, ok := state[name]; ok && !refreshed[name].Equal(expected) {
+					t.Errorf("expected %s %v, got %v", name, expected, refreshed[name])
+				}
+			}
+
+			requiresReplace, diagnostics := testPlanResourceUpdate(t, providerConfig, "pastebin_paste", refreshed, config)
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected plan error %v", diagnostics)
+			}
+			for _, attributePath := range requiresReplace {
+				if attributePath.Equal(tftypes.NewAttributePath().WithAttributeName("content_hash")) {
+					t.Errorf("expected the trimmed paste not to be replaced, got %v", requiresReplace)
+				}
+			}
+		})
+	}
+}
+
+func TestPasteResourceExpiresAt(t *testing.T) {
+	testCases := map[string]struct {
+		expire    tftypes.Value
+		createdAt tftypes.Value
+		expected  tftypes.Value
+	}{
+		"10 minutes": {
+			expire:    tftypes.NewValue(tftypes.String, "10M"),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-31T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, "2024-01-31T12:10:00Z"),
+		},
+		"1 hour": {
+			expire:    tftypes.NewValue(tftypes.String, "1H"),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-31T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, "2024-01-31T13:00:00Z"),
+		},
+		"1 day": {
+			expire:    tftypes.NewValue(tftypes.String, "1D"),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-31T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, "2024-02-01T12:00:00Z"),
+		},
+		"1 week": {
+			expire:    tftypes.NewValue(tftypes.String, "1W"),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-31T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, "2024-02-07T12:00:00Z"),
+		},
+		"2 weeks": {
+			expire:    tftypes.NewValue(tftypes.String, "2W"),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-31T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, "2024-02-14T12:00:00Z"),
+		},
+		"1 month": {
+			expire:    tftypes.NewValue(tftypes.String, "1M"),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-15T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, "2024-02-15T12:00:00Z"),
+		},
+		"6 months": {
+			expire:    tftypes.NewValue(tftypes.String, "6M"),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-31T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, "2024-07-31T12:00:00Z"),
+		},
+		"1 year": {
+			expire:    tftypes.NewValue(tftypes.String, "1Y"),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-31T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, "2025-01-31T12:00:00Z"),
+		},
+		"alias": {
+			expire:    tftypes.NewValue(tftypes.String, "1 week"),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-31T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, "2024-02-07T12:00:00Z"),
+		},
+		"never": {
+			expire:    tftypes.NewValue(tftypes.String, "N"),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-31T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, nil),
+		},
+		"no expire": {
+			expire:    tftypes.NewValue(tftypes.String, nil),
+			createdAt: tftypes.NewValue(tftypes.String, "2024-01-31T12:00:00Z"),
+			expected:  tftypes.NewValue(tftypes.String, nil),
+		},
+		"unknown creation date": {
+			expire:    tftypes.NewValue(tftypes.String, "1D"),
+			createdAt: tftypes.NewValue(tftypes.String, nil),
+			expected:  tftypes.NewValue(tftypes.String, nil),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			id := server.AddPaste(pastebintest.Paste{Content: "Hello from Terraform.", Owner: server.UserKey})
+
+			refreshed, diagnostics := testReadResource(t, testFakeProviderConfig(server), "pastebin_paste", map[string]tftypes.Value{
+				"id":         tftypes.NewValue(tftypes.String, id),
+				"content":    tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"expire":     testCase.expire,
+				"created_at": testCase.createdAt,
+			})
+			if testHasError(diagnostics) || refreshed == nil {
+				t.Fatalf("unexpected read error %v", diagnostics)
+			}
+			if !refreshed["expires_at"].Equal(testCase.expected) {
+				t.Errorf("expected expires_at %v, got %v", testCase.expected, refreshed["expires_at"])
+			}
+		})
+	}
+}
+
+func TestPasteResourceCreateExpiresAt(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+
+	state, diagnostics := testApplyResourceCreate(t, testFakeProviderConfig(server), "pastebin_paste", map[string]tftypes.Value{
+		"content": tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+		"expire":  tftypes.NewValue(tftypes.String, "1D"),
+	})
+	if testHasError(diagnostics) {
+		t.Fatalf("unexpected error %v", diagnostics)
+	}
+	var createdAt, expiresAt string
+	if err := state["created_at"].As(&createdAt); err != nil {
+		t.Fatalf("unexpected created_at %v", state["created_at"])
+	}
+	if err := state["expires_at"].As(&expiresAt); err != nil {
+		t.Fatalf("unexpected expires_at %v", state["expires_at"])
+	}
+	created, _ := time.Parse(time.RFC3339, createdAt)
+	expires, _ := time.Parse(time.RFC3339, expiresAt)
+	if expires.Sub(created) != 24*time.Hour {
+		t.Errorf("expected expires_at %s to be a day after created_at %s", expiresAt, createdAt)
+	}
+}
+
+func TestPasteResourceContentCharset(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	providerConfig := testFakeProviderConfig(server)
+
+	state, diagnostics := testApplyResourceCreate(t, providerConfig, "pastebin_paste", map[string]tftypes.Value{
+		"content":         tftypes.NewValue(tftypes.String, "Café"),
+		"content_charset": tftypes.NewValue(tftypes.String, "iso-8859-1"),
+	})
+	if testHasError(diagnostics) {
+		t.Fatalf("unexpected error %v", diagnostics)
+	}
+	var id string
+	if err := state["id"].As(&id); err != nil {
+		t.Fatal(err)
+	}
+	if paste, _ := server.Paste(id); paste.Content != "Caf\xe9" {
+		t.Errorf("expected the paste to be stored in iso-8859-1, got %q", paste.Content)
+	}
+
+	refreshed, diagnostics := testReadResource(t, providerConfig, "pastebin_paste", map[string]tftypes.Value{
+		"id":              state["id"],
+		"content":         tftypes.NewValue(tftypes.String, "Café"),
+		"content_charset": tftypes.NewValue(tftypes.String, "iso-8859-1"),
+	})
+	if testHasError(diagnostics) || refreshed == nil {
+		t.Fatalf("unexpected read error %v", diagnostics)
+	}
+	if !refreshed["content"].Equal(tftypes.NewValue(tftypes.String, "Café")) {
+		t.Errorf("expected the content to be decoded, got %v", refreshed["content"])
+	}
+
+	_, diagnostics = testApplyResourceCreate(t, providerConfig, "pastebin_paste", map[string]tftypes.Value{
+		"content":         tftypes.NewValue(tftypes.String, "5 €"),
+		"content_charset": tftypes.NewValue(tftypes.String, "iso-8859-1"),
+	})
+	if !testHasError(diagnostics) {
+		t.Error("expected an error for content that the charset cannot represent")
+	}
+}
+
+func TestPasteResourceCreateMatchExistingByTitle(t *testing.T) {
+	testCases := map[string]struct {
+		existing  []pastebintest.Paste
+		content   string
+		trim      bool
+		expectErr bool
+		adopted   bool
+		deleted   bool
+	}{
+		"adopt": {
+			existing: []pastebintest.Paste{
+				{Key: "existing", Title: "Greeting", Content: "Hello from Terraform.", Private: "1", Owner: "user"},
+			},
+			adopted: true,
+		},
+		"adopt trailing whitespace trimmed by pastebin": {
+			existing: []pastebintest.Paste{
+				{Key: "existing", Title: "Greeting", Content: "Hello from Terraform.", Private: "1", Owner: "user"},
+			},
+			content: "Hello from Terraform.\n\n",
+			trim:    true,
+			adopted: true,
+		},
+		"content mismatch": {
+			existing: []pastebintest.Paste{
+				{Key: "existing", Title: "Greeting", Content: "Hello from Pastebin.", Private: "1", Owner: "user"},
+			},
+			deleted: true,
+		},
+		"visibility mismatch": {
+			existing: []pastebintest.Paste{
+				{Key: "existing", Title: "Greeting", Content: "Hello from Terraform.", Private: "0", Owner: "user"},
+			},
+			deleted: true,
+		},
+		"multiple matches": {
+			existing: []pastebintest.Paste{
+				{Key: "existing", Title: "Greeting", Content: "Hello from Terraform.", Private: "1", Owner: "user"},
+				{Key: "duplicate", Title: "Greeting", Content: "Hello from Terraform.", Private: "1", Owner: "user"},
+			},
+			expectErr: true,
+		},
+		"no match": {
+			existing: []pastebintest.Paste{
+				{Key: "existing", Title: "Farewell", Content: "Hello from Terraform.", Private: "1", Owner: "user"},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			for _, paste := range testCase.existing {
+				server.AddPaste(paste)
+			}
+
+			content := "Hello from Terraform."
+			if testCase.content != "" {
+				content = testCase.content
+			}
+			state, diagnostics := testApplyResourceCreate(t, testFakeProviderConfig(server), "pastebin_paste", map[string]tftypes.Value{
+				"content":                  tftypes.NewValue(tftypes.String, content),
+				"title":                    tftypes.NewValue(tftypes.String, "Greeting"),
+				"trim_trailing_whitespace": tftypes.NewValue(tftypes.Bool, testCase.trim),
+				"match_existing_by_title":  tftypes.NewValue(tftypes.Bool, true),
+			})
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Fatalf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+			if testCase.expectErr {
+				if server.Requests("paste") != 0 {
+					t.Error("expected no paste to be created")
+				}
+				return
+			}
+			adopted := state["id"].Equal(tftypes.NewValue(tftypes.String, "existing"))
+			if adopted != testCase.adopted {
+				t.Errorf("expected adopted %t, got id %v", testCase.adopted, state["id"])
+			}
+			if created := server.Requests("paste"); (created == 0) != testCase.adopted {
+				t.Errorf("expected adopted %t, got %d created pastes", testCase.adopted, created)
+			}
+			if _, exists := server.Paste("existing"); exists == testCase.deleted {
+				t.Errorf("expected deleted %t for the existing paste", testCase.deleted)
+			}
+		})
+	}
+}
+
+func TestPasteResourceCreateIfAbsent(t *testing.T) {
+	testCases := map[string]struct {
+		existing []pastebintest.Paste
+		adopted  bool
+	}{
+		"adopt": {
+			existing: []pastebintest.Paste{
+				{Key: "other", Title: "Other", Content: "Hello from Pastebin.", Private: "1", Owner: "user"},
+				{Key: "existing", Title: "Untitled", Content: "Hello from Terraform.", Private: "1", Owner: "user"},
+			},
+			adopted: true,
+		},
+		"content mismatch": {
+			existing: []pastebintest.Paste{
+				{Key: "existing", Title: "Greeting", Content: "Hello from Pastebin.", Private: "1", Owner: "user"},
+			},
+		},
+		"visibility mismatch": {
+			existing: []pastebintest.Paste{
+				{Key: "existing", Title: "Greeting", Content: "Hello from Terraform.", Private: "0", Owner: "user"},
+			},
+		},
+		"other user": {
+			existing: []pastebintest.Paste{
+				{Key: "existing", Title: "Greeting", Content: "Hello from Terraform.", Private: "1", Owner: "other"},
+			},
+		},
+		"no pastes": {},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			for _, paste := range testCase.existing {
+				server.AddPaste(paste)
+			}
+
+			state, diagnostics := testApplyResourceCreate(t, testFakeProviderConfig(server), "pastebin_paste", map[string]tftypes.Value{
+				"content":          tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"title":            tftypes.NewValue(tftypes.String, "Greeting"),
+				"create_if_absent": tftypes.NewValue(tftypes.Bool, true),
+			})
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error: %v", diagnostics)
+			}
+			adopted := state["id"].Equal(tftypes.NewValue(tftypes.String, "existing"))
+			if adopted != testCase.adopted {
+				t.Errorf("expected adopted %t, got id %v", testCase.adopted, state["id"])
+			}
+			if created := server.Requests("paste"); (created == 0) != testCase.adopted {
+				t.Errorf("expected adopted %t, got %d created pastes", testCase.adopted, created)
+			}
+			for _, paste := range testCase.existing {
+				if _, exists := server.Paste(paste.Key); !exists {
+					t.Errorf("expected paste %s to be kept", paste.Key)
+				}
+			}
+		})
+	}
+}
+
+func TestPasteTitleFromContent(t *testing.T) {
+	testCases := map[string]struct {
+		content  string
+		expected string
+	}{
+		"single line":      {content: "Hello from Terraform.", expected: "Hello from Terraform."},
+		"multi line":       {content: "# Deployment notes\nStep 1\nStep 2\n", expected: "# Deployment notes"},
+		"windows newlines": {content: "Deployment notes\r\nStep 1", expected: "Deployment notes"},
+		"surrounding":      {content: "  Deployment notes \t\nStep 1", expected: "Deployment notes"},
+		"empty first line": {content: "\nStep 1", expected: ""},
+		"long line":        {content: strings.Repeat("a", maxPasteTitleLength+10), expected: strings.Repeat("a", maxPasteTitleLength)},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if title := pasteTitleFromContent(testCase.content); title != testCase.expected {
+				t.Errorf("expected title %q, got %q", testCase.expected, title)
+			}
+		})
+	}
+}
+
+func TestPasteResourceCreateGeneratedTitle(t *testing.T) {
+	testCases := map[string]struct {
+		config   map[string]tftypes.Value
+		expected tftypes.Value
+	}{
+		"multi line": {
+			config: map[string]tftypes.Value{
+				"content":                     tftypes.NewValue(tftypes.String, "Deployment notes\nStep 1\nStep 2"),
+				"generate_title_from_content": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: tftypes.NewValue(tftypes.String, "Deployment notes"),
+		},
+		"single line": {
+			config: map[string]tftypes.Value{
+				"content":                     tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+				"generate_title_from_content": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: tftypes.NewValue(tftypes.String, "Hello from Terraform."),
+		},
+		"configured title": {
+			config: map[string]tftypes.Value{
+				"content":                     tftypes.NewValue(tftypes.String, "Deployment notes\nStep 1"),
+				"title":                       tftypes.NewValue(tftypes.String, "Notes"),
+				"generate_title_from_content": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: tftypes.NewValue(tftypes.String, "Notes"),
+		},
+		"disabled": {
+			config: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "Deployment notes\nStep 1"),
+			},
+			expected: tftypes.NewValue(tftypes.String, nil),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+
+			state, diagnostics := testApplyResourceCreate(t, testFakeProviderConfig(server), "pastebin_paste", testCase.config)
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			if !state["title"].Equal(testCase.expected) {
+				t.Errorf("expected title %v, got %v", testCase.expected, state["title"])
+			}
+			var id, expected string
+			if err := state["id"].As(&id); err != nil {
+				t.Fatal(err)
+			}
+			if err := testCase.expected.As(&expected); err != nil {
+				t.Fatal(err)
+			}
+			if paste, _ := server.Paste(id); paste.Title != expected {
+				t.Errorf("expected the paste to be created with title %q, got %q", expected, paste.Title)
+			}
+		})
+	}
+}
+
+func TestPasteResourceCreateAutoDetectFormat(t *testing.T) {
+	sourceDir := t.TempDir()
+	for _, name := range []string{"main.go", "notes.unknownext"} {
+		if err := os.WriteFile(filepath.Join(sourceDir, name), []byte("Hello from a file."), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := map[string]struct {
+		config   map[string]tftypes.Value
+		expected string
+	}{
+		"known extension": {
+			config: map[string]tftypes.Value{
+				"source_file":        tftypes.NewValue(tftypes.String, filepath.Join(sourceDir, "main.go")),
+				"auto_detect_format": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: "go",
+		},
+		"unknown extension": {
+			config: map[string]tftypes.Value{
+				"source_file":        tftypes.NewValue(tftypes.String, filepath.Join(sourceDir, "notes.unknownext")),
+				"auto_detect_format": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: "text",
+		},
+		"configured format": {
+			config: map[string]tftypes.Value{
+				"source_file":        tftypes.NewValue(tftypes.String, filepath.Join(sourceDir, "main.go")),
+				"format":             tftypes.NewValue(tftypes.String, "c"),
+				"auto_detect_format": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: "c",
+		},
+		"disabled": {
+			config: map[string]tftypes.Value{
+				"source_file": tftypes.NewValue(tftypes.String, filepath.Join(sourceDir, "main.go")),
+			},
+			expected: "yaml",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			providerConfig := testFakeProviderConfig(server)
+			providerConfig["default_format"] = tftypes.NewValue(tftypes.String, "yaml")
+
+			state, diagnostics := testApplyResourceCreate(t, providerConfig, "pastebin_paste", testCase.config)
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			if !state["format"].Equal(tftypes.NewValue(tftypes.String, testCase.expected)) {
+				t.Errorf("expected format %q, got %v", testCase.expected, state["format"])
+			}
+			var id string
+			if err := state["id"].As(&id); err != nil {
+				t.Fatal(err)
+			}
+			if paste, _ := server.Paste(id); paste.Format != testCase.expected {
+				t.Errorf("expected the paste to be created with format %q, got %q", testCase.expected, paste.Format)
+			}
+		})
+	}
+}
+
+func TestPasteResourcePlanGeneratedTitle(t *testing.T) {
+	providerConfig := map[string]tftypes.Value{
+		"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+	}
+	state := map[string]tftypes.Value{
+		"id":                          tftypes.NewValue(tftypes.String, "abcd1234"),
+		"content":                     tftypes.NewValue(tftypes.String, "Deployment notes\nStep 1"),
+		"content_sensitive":           tftypes.NewValue(tftypes.Bool, false),
+		"title":                       tftypes.NewValue(tftypes.String, "Deployment notes"),
+		"generate_title_from_content": tftypes.NewValue(tftypes.Bool, true),
+		"visibility":                  tftypes.NewValue(tftypes.String, "unlisted"),
+		"normalize_line_endings":      tftypes.NewValue(tftypes.Bool, false),
+		"recreate_on_update":          tftypes.NewValue(tftypes.Bool, true),
+	}
+
+	testCases := map[string]struct {
+		content       string
+		expectReplace bool
+	}{
+		"same first line":    {content: "Deployment notes\nStep 1\nStep 2"},
+		"changed first line": {content: "Release notes\nStep 1", expectReplace: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"content":                     tftypes.NewValue(tftypes.String, testCase.content),
+				"generate_title_from_content": tftypes.NewValue(tftypes.Bool, true),
+				"recreate_on_update":          tftypes.NewValue(tftypes.Bool, true),
+			}
+			requiresReplace, diagnostics := testPlanResourceUpdate(t, providerConfig, "pastebin_paste", state, config)
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			replaced := false
+			for _, attributePath := range requiresReplace {
+				if attributePath.Equal(tftypes.NewAttributePath().WithAttributeName("title")) {
+					replaced = true
+				}
+			}
+			if replaced != testCase.expectReplace {
+				t.Errorf("expected replace %t, got %v", testCase.expectReplace, requiresReplace)
+			}
+		})
+	}
+}
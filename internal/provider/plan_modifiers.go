@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// requiresReplaceUnlessRecreateOnUpdate returns a plan modifier that requires
+// a replacement of the paste when the value changes, unless recreate_on_update
+// is enabled in which case the update recreates the paste instead.
+func requiresReplaceUnlessRecreateOnUpdate() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var recreateOnUpdate types.Bool
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("recreate_on_update"), &recreateOnUpdate)...)
+			resp.RequiresReplace = !recreateOnUpdate.ValueBool()
+		},
+		"If the value of this attribute changes, Terraform will destroy and recreate the resource, unless recreate_on_update is enabled.",
+		"If the value of this attribute changes, Terraform will destroy and recreate the resource, unless `recreate_on_update` is enabled.",
+	)
+}
+
+// expireRequiresReplaceUnlessRecreateOnUpdate returns a plan modifier that
+// requires a replacement of the paste when its expiration changes, unless
+// recreate_on_update is enabled. Changing between an alias and its code does
+// not change the expiration.
+func expireRequiresReplaceUnlessRecreateOnUpdate() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			stateExpire, _ := normalizeExpire(req.StateValue.ValueString())
+			planExpire, known := normalizeExpire(req.PlanValue.ValueString())
+			if known && stateExpire == planExpire {
+				return
+			}
+			var recreateOnUpdate types.Bool
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("recreate_on_update"), &recreateOnUpdate)...)
+			resp.RequiresReplace = !recreateOnUpdate.ValueBool()
+		},
+		"If the expiration changes, Terraform will destroy and recreate the resource, unless recreate_on_update is enabled.",
+		"If the expiration changes, Terraform will destroy and recreate the resource, unless `recreate_on_update` is enabled.",
+	)
+}
+
+// charsetRequiresReplaceUnlessRecreateOnUpdate returns a plan modifier that
+// requires a replacement of the paste when its charset changes, unless
+// recreate_on_update is enabled. A state without a charset, such as that of an
+// imported paste or of a paste created before the charset was configurable,
+// uses the default charset.
+func charsetRequiresReplaceUnlessRecreateOnUpdate() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			if req.StateValue.IsNull() && req.PlanValue.ValueString() == defaultCharset {
+				return
+			}
+			var recreateOnUpdate types.Bool
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("recreate_on_update"), &recreateOnUpdate)...)
+			resp.RequiresReplace = !recreateOnUpdate.ValueBool()
+		},
+		"If the charset changes, Terraform will destroy and recreate the resource, unless recreate_on_update is enabled.",
+		"If the charset changes, Terraform will destroy and recreate the resource, unless `recreate_on_update` is enabled.",
+	)
+}
+
+// titleFromContentModifier is a plan modifier that plans the title of a paste
+// without a configured title, which is derived from its content if
+// generate_title_from_content is enabled, or null otherwise.
+type titleFromContentModifier struct{}
+
+// titleFromContent returns a plan modifier that plans the title of a paste
+// without a configured title.
+func titleFromContent() planmodifier.String {
+	return titleFromContentModifier{}
+}
+
+// Description describes the plan modification in plain text formatting.
+func (m titleFromContentModifier) Description(_ context.Context) string {
+	return "If not configured, the title is the first line of the content if generate_title_from_content is enabled."
+}
+
+// MarkdownDescription describes the plan modification in Markdown formatting.
+func (m titleFromContentModifier) MarkdownDescription(_ context.Context) string {
+	return "If not configured, the title is the first line of the content if `generate_title_from_content` is enabled."
+}
+
+// PlanModifyString plans the title.
+func (m titleFromContentModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var plan pasteResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.GenerateTitleFromContent.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	if !plan.GenerateTitleFromContent.ValueBool() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+
+	// The title is known once the content is known, and the errors of the
+	// content are reported when the plan of the paste is modified
+	resp.PlanValue = types.StringUnknown()
+	if plan.Content.IsUnknown() || plan.ContentBase64.IsUnknown() || plan.SourceFile.IsUnknown() {
+		return
+	}
+	content, diags := plan.content()
+	if diags.HasError() {
+		return
+	}
+	resp.PlanValue = types.StringValue(pasteTitleFromContent(content))
+}
+
+// pasteTitleFromContent returns the first line of the content without
+// surrounding whitespace, truncated to the maximum length of a paste title.
+// The title is empty if the first line is empty.
+func pasteTitleFromContent(content string) string {
+	line, _, _ := strings.Cut(content, "\n")
+	title := strings.TrimSpace(line)
+	if utf8.RuneCountInString(title) <= maxPasteTitleLength {
+		return title
+	}
+	return strings.TrimSpace(string([]rune(title)[:maxPasteTitleLength]))
+}
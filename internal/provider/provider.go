@@ -2,28 +2,52 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/simonkarman/pastebin-client-go"
+	"io"
+	"net/http"
 	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &pastebinProvider{}
+	_ provider.Provider                     = &pastebinProvider{}
+	_ provider.ProviderWithConfigValidators = &pastebinProvider{}
+	_ provider.ProviderWithFunctions        = &pastebinProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
 func New(version string) func() provider.Provider {
+	return NewWithObserver(version, noopObserver{})
+}
+
+// NewWithObserver is like New, but reports every request to the PasteBin API
+// to the observer, for programs that embed the provider.
+func NewWithObserver(version string, observer RequestObserver) func() provider.Provider {
+	if observer == nil {
+		observer = noopObserver{}
+	}
 	return func() provider.Provider {
 		return &pastebinProvider{
-			version: version,
+			version:  version,
+			observer: observer,
 		}
 	}
 }
@@ -34,6 +58,9 @@ type pastebinProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// observer is reported every request to the PasteBin API.
+	observer RequestObserver
 }
 
 // Metadata returns the provider type name.
@@ -42,11 +69,57 @@ func (p *pastebinProvider) Metadata(_ context.Context, _ provider.MetadataReques
 	resp.Version = p.version
 }
 
+// pastebinProviderData is the provider configured data that is shared with
+// the data sources and resources.
+type pastebinProviderData struct {
+	Client            *pastebinClient
+	DefaultFormat     string
+	DefaultExpire     string
+	DefaultVisibility string
+
+	// LargePasteWarnBytes is the size of paste content above which a plan
+	// warns, or 0 if it never warns.
+	LargePasteWarnBytes int64
+
+	// SkipApiOnPlan defers the checks that request the PasteBin API from
+	// planning a resource to applying it.
+	SkipApiOnPlan bool
+}
+
 // Schema defines the provider-level schema for configuration data.
 type pastebinProviderModel struct {
-	Host    types.String `tfsdk:"host"`
-	DevKey  types.String `tfsdk:"dev_key"`
-	UserKey types.String `tfsdk:"user_key"`
+	Host                  types.String `tfsdk:"host"`
+	BasePath              types.String `tfsdk:"base_path"`
+	DevKey                types.String `tfsdk:"dev_key"`
+	UserKey               types.String `tfsdk:"user_key"`
+	DevKeyFile            types.String `tfsdk:"dev_key_file"`
+	UserKeyFile           types.String `tfsdk:"user_key_file"`
+	CredentialsFile       types.String `tfsdk:"credentials_file"`
+	UserKeyCommand        types.List   `tfsdk:"user_key_command"`
+	ScrapingApiKey        types.String `tfsdk:"scraping_api_key"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RetryMinDelay         types.String `tfsdk:"retry_min_delay"`
+	Timeout               types.String `tfsdk:"timeout"`
+	ProxyUrl              types.String `tfsdk:"proxy_url"`
+	UserAgent             types.String `tfsdk:"user_agent"`
+	RequestsPerMinute     types.Int64  `tfsdk:"requests_per_minute"`
+	RetryBudgetPerMinute  types.Int64  `tfsdk:"retry_budget_per_minute"`
+	DefaultFormat         types.String `tfsdk:"default_format"`
+	DefaultExpire         types.String `tfsdk:"default_expire"`
+	DefaultVisibility     types.String `tfsdk:"default_visibility"`
+	TlsInsecureSkipVerify types.Bool   `tfsdk:"tls_insecure_skip_verify"`
+	CaCertFile            types.String `tfsdk:"ca_cert_file"`
+	VerifyConnection      types.Bool   `tfsdk:"verify_connection"`
+	SkipApiOnPlan         types.Bool   `tfsdk:"skip_api_on_plan"`
+	MaxInlineBytes        types.Int64  `tfsdk:"max_inline_bytes"`
+	LargePasteWarnBytes   types.Int64  `tfsdk:"large_paste_warn_bytes"`
+	MaxIdleConns          types.Int64  `tfsdk:"max_idle_conns"`
+	CompressReads         types.Bool   `tfsdk:"compress_reads"`
+	RequireExplicitHost   types.Bool   `tfsdk:"require_explicit_host"`
+	CacheReads            types.Bool   `tfsdk:"cache_reads"`
+	EnableRequestTiming   types.Bool   `tfsdk:"enable_request_timing"`
+	StrictOrdering        types.Bool   `tfsdk:"strict_ordering"`
+	ForceHttp1            types.Bool   `tfsdk:"force_http1"`
 }
 
 func (p *pastebinProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
@@ -55,6 +128,11 @@ func (p *pastebinProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 			"host": schema.StringAttribute{
 				Optional: true,
 			},
+			// The path of a Pastebin compatible instance that is not at the root
+			// of its host, such as /pastebin.
+			"base_path": schema.StringAttribute{
+				Optional: true,
+			},
 			"dev_key": schema.StringAttribute{
 				Optional:  true,
 				Sensitive: true,
@@ -63,10 +141,130 @@ func (p *pastebinProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Optional:  true,
 				Sensitive: true,
 			},
+			"dev_key_file": schema.StringAttribute{
+				Optional: true,
+			},
+			"user_key_file": schema.StringAttribute{
+				Optional: true,
+			},
+			// A JSON file with the host, dev_key and user_key, which the other
+			// configuration values take precedence over.
+			"credentials_file": schema.StringAttribute{
+				Optional: true,
+			},
+			// The command is run without a shell, and its output is the user key.
+			"user_key_command": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			// Reads pastes through the scraping api of Pastebin PRO instead of
+			// the raw endpoint.
+			"scraping_api_key": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional: true,
+			},
+			"retry_min_delay": schema.StringAttribute{
+				Optional: true,
+			},
+			"timeout": schema.StringAttribute{
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional: true,
+			},
+			"user_agent": schema.StringAttribute{
+				Optional: true,
+			},
+			"requests_per_minute": schema.Int64Attribute{
+				Optional: true,
+			},
+			// The retries of all requests together, as opposed to max_retries
+			// which limits the retries of a single request.
+			"retry_budget_per_minute": schema.Int64Attribute{
+				Optional: true,
+			},
+			"default_format": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					pasteFormat(),
+				},
+			},
+			"default_expire": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					pasteExpire(),
+				},
+			},
+			"default_visibility": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringOneOf("public", "unlisted", "private"),
+				},
+			},
+			"tls_insecure_skip_verify": schema.BoolAttribute{
+				Optional: true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional: true,
+			},
+			"verify_connection": schema.BoolAttribute{
+				Optional: true,
+			},
+			// Plans resources without requests, for fast and offline plans.
+			"skip_api_on_plan": schema.BoolAttribute{
+				Optional: true,
+			},
+			"max_inline_bytes": schema.Int64Attribute{
+				Optional: true,
+			},
+			// Warns about, but does not prevent, pastes above this size.
+			"large_paste_warn_bytes": schema.Int64Attribute{
+				Optional: true,
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Optional: true,
+			},
+			"compress_reads": schema.BoolAttribute{
+				Optional: true,
+			},
+			// Disables HTTP/2, for hosts behind proxies with a broken HTTP/2
+			// implementation.
+			"force_http1": schema.BoolAttribute{
+				Optional: true,
+			},
+			"cache_reads": schema.BoolAttribute{
+				Optional: true,
+			},
+			// Logs the latency of every request at info level, instead of only with
+			// the debug logs of the requests.
+			"enable_request_timing": schema.BoolAttribute{
+				Optional: true,
+			},
+			// Creates pastes one at a time, for accounts that Pastebin rejects
+			// concurrent creates of.
+			"strict_ordering": schema.BoolAttribute{
+				Optional: true,
+			},
+			// Guards self-hosted setups against talking to pastebin.com by accident.
+			"require_explicit_host": schema.BoolAttribute{
+				Optional: true,
+			},
 		},
 	}
 }
 
+// ConfigValidators returns the validators of the provider configuration.
+func (p *pastebinProvider) ConfigValidators(_ context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		requiresWith(path.Root("user_key"), "PASTEBIN_DEV_KEY", path.Root("dev_key"), path.Root("dev_key_file"), path.Root("credentials_file")),
+		requiresWith(path.Root("user_key_file"), "PASTEBIN_DEV_KEY", path.Root("dev_key"), path.Root("dev_key_file"), path.Root("credentials_file")),
+		requiresWith(path.Root("user_key_command"), "PASTEBIN_DEV_KEY", path.Root("dev_key"), path.Root("dev_key_file"), path.Root("credentials_file")),
+	}
+}
+
 // Configure prepares a pastebin API client for data sources and resources.
 func (p *pastebinProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	// Retrieve provider data from configuration
@@ -107,32 +305,338 @@ func (p *pastebinProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	if config.DevKeyFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dev_key_file"),
+			"Unknown PasteBin API Dev Key File",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the PasteBin API dev key file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PASTEBIN_DEV_KEY environment variable.",
+		)
+	}
+
+	if config.UserKeyFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_key_file"),
+			"Unknown PasteBin API User Key File",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the PasteBin API user key file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PASTEBIN_USER_KEY environment variable.",
+		)
+	}
+
+	if config.CredentialsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unknown PasteBin API Credentials File",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the PasteBin API credentials file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PASTEBIN_HOST, PASTEBIN_DEV_KEY and PASTEBIN_USER_KEY environment variables.",
+		)
+	}
+
+	if config.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown PasteBin API Max Retries",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the maximum number of retries. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RetryMinDelay.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_min_delay"),
+			"Unknown PasteBin API Retry Min Delay",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the minimum retry delay. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Timeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timeout"),
+			"Unknown PasteBin API Timeout",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the request timeout. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ProxyUrl.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown PasteBin API Proxy URL",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the proxy url. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the HTTPS_PROXY environment variable.",
+		)
+	}
+
+	if config.BasePath.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_path"),
+			"Unknown PasteBin API Base Path",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the PasteBin API base path. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.UserAgent.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_agent"),
+			"Unknown PasteBin API User Agent",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the user agent. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RequestsPerMinute.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_minute"),
+			"Unknown PasteBin API Requests Per Minute",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the requests per minute. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RetryBudgetPerMinute.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_budget_per_minute"),
+			"Unknown PasteBin API Retry Budget Per Minute",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the retry budget per minute. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.TlsInsecureSkipVerify.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_insecure_skip_verify"),
+			"Unknown PasteBin API TLS Insecure Skip Verify",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for skipping TLS verification. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.CaCertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Unknown PasteBin API CA Certificate File",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the CA certificate file. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.VerifyConnection.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("verify_connection"),
+			"Unknown PasteBin API Verify Connection",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for verifying the connection. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.SkipApiOnPlan.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("skip_api_on_plan"),
+			"Unknown PasteBin API Skip API On Plan",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for skipping the api on plan. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxInlineBytes.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_inline_bytes"),
+			"Unknown PasteBin API Max Inline Bytes",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the max inline bytes. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.LargePasteWarnBytes.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("large_paste_warn_bytes"),
+			"Unknown PasteBin API Large Paste Warn Bytes",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the large paste warn bytes. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxIdleConns.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_idle_conns"),
+			"Unknown PasteBin API Max Idle Connections",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the maximum number of idle connections. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.UserKeyCommand.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_key_command"),
+			"Unknown PasteBin API User Key Command",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the user key command. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.CompressReads.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("compress_reads"),
+			"Unknown PasteBin API Compress Reads",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for compressing reads. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ForceHttp1.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("force_http1"),
+			"Unknown PasteBin API Force HTTP1",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for forcing HTTP/1.1. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RequireExplicitHost.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("require_explicit_host"),
+			"Unknown PasteBin API Require Explicit Host",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for requiring an explicit host. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.CacheReads.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cache_reads"),
+			"Unknown PasteBin API Cache Reads",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for caching reads. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.EnableRequestTiming.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("enable_request_timing"),
+			"Unknown PasteBin API Enable Request Timing",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for enabling request timing. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ScrapingApiKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("scraping_api_key"),
+			"Unknown PasteBin API Scraping API Key",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for the PasteBin API scraping api key. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PASTEBIN_SCRAPING_API_KEY environment variable.",
+		)
+	}
+
+	if config.StrictOrdering.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("strict_ordering"),
+			"Unknown PasteBin API Strict Ordering",
+			"The provider cannot create the PasteBin API client as there is an unknown configuration value for strict ordering. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
+	// with the credentials file, and with Terraform configuration value
+	// or the content of the configured file if set.
 	host := os.Getenv("PASTEBIN_HOST")
 	devKey := os.Getenv("PASTEBIN_DEV_KEY")
 	userKey := os.Getenv("PASTEBIN_USER_KEY")
 
+	if !config.CredentialsFile.IsNull() {
+		credentials := readCredentialsFile(path.Root("credentials_file"), config.CredentialsFile.ValueString(), &resp.Diagnostics)
+		if credentials.Host != "" {
+			host = credentials.Host
+			warnOverriddenEnv(path.Root("credentials_file"), "PASTEBIN_HOST", host, &resp.Diagnostics)
+		}
+		if credentials.DevKey != "" {
+			devKey = credentials.DevKey
+			warnOverriddenEnv(path.Root("credentials_file"), "PASTEBIN_DEV_KEY", devKey, &resp.Diagnostics)
+		}
+		if credentials.UserKey != "" {
+			userKey = credentials.UserKey
+			warnOverriddenEnv(path.Root("credentials_file"), "PASTEBIN_USER_KEY", userKey, &resp.Diagnostics)
+		}
+	}
+
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
+		warnOverriddenEnv(path.Root("host"), "PASTEBIN_HOST", host, &resp.Diagnostics)
 	}
 
 	if !config.DevKey.IsNull() {
 		devKey = config.DevKey.ValueString()
+		warnOverriddenEnv(path.Root("dev_key"), "PASTEBIN_DEV_KEY", devKey, &resp.Diagnostics)
+	} else if !config.DevKeyFile.IsNull() {
+		devKey = readKeyFile(path.Root("dev_key_file"), config.DevKeyFile.ValueString(), &resp.Diagnostics)
+		warnOverriddenEnv(path.Root("dev_key_file"), "PASTEBIN_DEV_KEY", devKey, &resp.Diagnostics)
 	}
 
 	if !config.UserKey.IsNull() {
 		userKey = config.UserKey.ValueString()
+		warnOverriddenEnv(path.Root("user_key"), "PASTEBIN_USER_KEY", userKey, &resp.Diagnostics)
+	} else if !config.UserKeyFile.IsNull() {
+		userKey = readKeyFile(path.Root("user_key_file"), config.UserKeyFile.ValueString(), &resp.Diagnostics)
+		warnOverriddenEnv(path.Root("user_key_file"), "PASTEBIN_USER_KEY", userKey, &resp.Diagnostics)
+	}
+
+	scrapingApiKey := os.Getenv("PASTEBIN_SCRAPING_API_KEY")
+	if !config.ScrapingApiKey.IsNull() {
+		scrapingApiKey = config.ScrapingApiKey.ValueString()
+		warnOverriddenEnv(path.Root("scraping_api_key"), "PASTEBIN_SCRAPING_API_KEY", scrapingApiKey, &resp.Diagnostics)
+	}
+
+	// The user key command fetches the user key if no user key is set, and
+	// fetches a fresh user key whenever the api rejects the user key, which
+	// supports backends that issue short-lived user keys.
+	var refreshUserKey func(ctx context.Context) (string, error)
+	if !config.UserKeyCommand.IsNull() {
+		var command []string
+		resp.Diagnostics.Append(config.UserKeyCommand.ElementsAs(ctx, &command, false)...)
+		var err error
+		refreshUserKey, err = newUserKeyCommand(command)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("user_key_command"),
+				"Invalid PasteBin API User Key Command",
+				"The provider cannot create the PasteBin API client as the user key command cannot be run: "+err.Error()+". "+
+					"Ensure the first element of user_key_command is an executable in the PATH or an absolute path to an executable.",
+			)
+		} else if userKey == "" {
+			userKey, err = refreshUserKey(ctx)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("user_key_command"),
+					"Unable to Fetch PasteBin API User Key",
+					"The provider cannot create the PasteBin API client as the user key command did not return a user key: "+err.Error(),
+				)
+			}
+		}
 	}
 
 	// If any of the expected configurations are missing, return
-	// errors with provider-specific guidance.
+	// errors with provider-specific guidance. An empty host is not an
+	// error, but falls back to the default host, unless an explicit host
+	// is required.
+	if host == "" && config.RequireExplicitHost.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Missing PasteBin API Host",
+			"The provider cannot create the PasteBin API client as there is a missing or empty value for the PasteBin API host, "+
+				"and require_explicit_host does not allow falling back to the default 'https://pastebin.com' url. "+
+				"Set the host value in the configuration or use the PASTEBIN_HOST environment variable.",
+		)
+		return
+	}
 	if host == "" {
-		host = "https://pastebin.com"
+		host = defaultHost
 	}
 	hostUrl, err := url.Parse(host)
 	if err != nil || hostUrl == nil {
@@ -143,9 +647,29 @@ func (p *pastebinProvider) Configure(ctx context.Context, req provider.Configure
 				"Ensure the host value or the PASTEBIN_HOST environment variable is set to a valid url. "+
 				"If you leave the host value empty, the default 'https://pastebin.com' url is used.",
 		)
+	} else if detail := invalidHostUrl(hostUrl); detail != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Invalid PasteBin API Host",
+			"The provider cannot create the PasteBin API client as the provided host "+host+" "+detail+". "+
+				"Ensure the host value or the PASTEBIN_HOST environment variable is set to a url such as 'https://pastebin.com'. "+
+				"Set the base_path value for an instance that is not at the root of its host.",
+		)
+	}
+
+	// The base path only consists of the path segments, which are joined
+	// with the host and the api paths.
+	basePath := config.BasePath.ValueString()
+	if strings.ContainsAny(basePath, "?#") || strings.Contains(basePath, "://") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_path"),
+			"Invalid PasteBin API Base Path",
+			"The provider cannot create the PasteBin API client as the provided base path "+basePath+" is not a path. "+
+				"Ensure the base_path value is a path such as '/pastebin', without a host, query or fragment.",
+		)
 	}
 
-	if devKey == "" {
+	if devKey == "" && config.DevKeyFile.IsNull() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("dev_key"),
 			"Missing PasteBin API Dev Key",
@@ -155,13 +679,202 @@ func (p *pastebinProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
-	if userKey == "" {
+	// Without a user key the client creates guest pastes. Operations that
+	// require an authenticated user check for the user key themselves.
+
+	// Retry transient failures 3 times, starting with a delay of 500ms,
+	// unless configured otherwise.
+	maxRetries := int64(3)
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+	if maxRetries < 0 {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("user_key"),
-			"Missing PasteBin API User Key",
-			"The provider cannot create the PasteBin API client as there is a missing or empty value for the PasteBin API user key. "+
-				"Set the dev_key value in the configuration or use the PASTEBIN_USER_KEY environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			path.Root("max_retries"),
+			"Invalid PasteBin API Max Retries",
+			"The provider cannot create the PasteBin API client as the maximum number of retries is negative. "+
+				"Set max_retries to 0 to disable retries.",
+		)
+	}
+
+	retryMinDelay := 500 * time.Millisecond
+	if !config.RetryMinDelay.IsNull() {
+		retryMinDelay, err = time.ParseDuration(config.RetryMinDelay.ValueString())
+		if err != nil || retryMinDelay < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_min_delay"),
+				"Invalid PasteBin API Retry Min Delay",
+				"The provider cannot create the PasteBin API client as the minimum retry delay is not a valid duration. "+
+					"Ensure the retry_min_delay value is a positive duration such as '500ms' or '2s'.",
+			)
+		}
+	}
+
+	// Requests time out after 30s, unless configured otherwise.
+	timeout := 30 * time.Second
+	if !config.Timeout.IsNull() {
+		timeout, err = time.ParseDuration(config.Timeout.ValueString())
+		if err != nil || timeout < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeout"),
+				"Invalid PasteBin API Timeout",
+				"The provider cannot create the PasteBin API client as the request timeout is not a valid duration. "+
+					"Ensure the timeout value is a positive duration such as '30s' or '1m', or '0s' to disable the timeout.",
+			)
+		}
+	}
+
+	// An explicit proxy url takes precedence over the HTTPS_PROXY, HTTP_PROXY
+	// and NO_PROXY environment variables.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if !config.ProxyUrl.IsNull() {
+		proxyUrl, err := url.Parse(config.ProxyUrl.ValueString())
+		if err != nil || proxyUrl.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid PasteBin API Proxy URL",
+				"The provider cannot create the PasteBin API client as the provided proxy url is not a valid URL. "+
+					"Ensure the proxy_url value is set to a valid url such as 'http://proxy.example.com:8080'.",
+			)
+		} else {
+			transport.Proxy = http.ProxyURL(proxyUrl)
+		}
+	}
+
+	// All data sources and resources share the client, so keep up to 10 idle
+	// connections to the host for reuse, unless configured otherwise. The
+	// default of 2 idle connections per host makes parallel applies of many
+	// pastes open a new connection for most requests.
+	maxIdleConns := int64(10)
+	if !config.MaxIdleConns.IsNull() {
+		maxIdleConns = config.MaxIdleConns.ValueInt64()
+	}
+	if maxIdleConns < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_idle_conns"),
+			"Invalid PasteBin API Max Idle Connections",
+			"The provider cannot create the PasteBin API client as the maximum number of idle connections is negative. "+
+				"Set max_idle_conns to 0 to close connections after every request.",
+		)
+	}
+	transport.MaxIdleConnsPerHost = int(maxIdleConns)
+	transport.DisableKeepAlives = maxIdleConns == 0
+
+	// Responses are gzip compressed, unless configured otherwise. The
+	// compression is handled by the gzipTransport instead of the transport.
+	transport.DisableCompression = true
+	var baseTransport http.RoundTripper = transport
+	if config.CompressReads.IsNull() || config.CompressReads.ValueBool() {
+		baseTransport = newGzipTransport(transport)
+	}
+
+	// Verify the host against the system certificates, and the certificates
+	// from the CA certificate file if configured.
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if config.TlsInsecureSkipVerify.ValueBool() {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("tls_insecure_skip_verify"),
+			"PasteBin API TLS Verification Disabled",
+			"The provider does not verify the TLS certificate of the PasteBin API host, "+
+				"which makes the connection vulnerable to man-in-the-middle attacks. "+
+				"Only disable verification for testing, and use ca_cert_file to trust a self-signed certificate instead.",
+		)
+	}
+	if !config.CaCertFile.IsNull() {
+		caCert, err := os.ReadFile(config.CaCertFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unreadable PasteBin API CA Certificate File",
+				"The provider cannot create the PasteBin API client as the CA certificate file could not be read: "+err.Error(),
+			)
+		} else {
+			certPool, err := x509.SystemCertPool()
+			if err != nil {
+				certPool = x509.NewCertPool()
+			}
+			if !certPool.AppendCertsFromPEM(caCert) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("ca_cert_file"),
+					"Invalid PasteBin API CA Certificate File",
+					"The provider cannot create the PasteBin API client as the CA certificate file does not contain any PEM encoded certificates.",
+				)
+			}
+			transport.TLSClientConfig.RootCAs = certPool
+		}
+	}
+
+	// HTTP/2 is negotiated with hosts that support it, unless configured
+	// otherwise.
+	if config.ForceHttp1.ValueBool() {
+		disableHTTP2(transport)
+	}
+
+	// Identify the provider on every request, unless configured otherwise.
+	userAgent := "terraform-provider-pastebin/" + p.version
+	if !config.UserAgent.IsNull() && config.UserAgent.ValueString() != "" {
+		userAgent = config.UserAgent.ValueString()
+	}
+
+	// Creating and deleting pastes is not rate limited, unless configured otherwise.
+	requestsPerMinute := int64(0)
+	if !config.RequestsPerMinute.IsNull() {
+		requestsPerMinute = config.RequestsPerMinute.ValueInt64()
+	}
+	if requestsPerMinute < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_minute"),
+			"Invalid PasteBin API Requests Per Minute",
+			"The provider cannot create the PasteBin API client as the requests per minute is negative. "+
+				"Set requests_per_minute to 0 or leave it empty to disable rate limiting.",
+		)
+	}
+
+	// The retries of all requests together are not limited, unless configured
+	// otherwise.
+	retryBudgetPerMinute := int64(0)
+	if !config.RetryBudgetPerMinute.IsNull() {
+		retryBudgetPerMinute = config.RetryBudgetPerMinute.ValueInt64()
+	}
+	if retryBudgetPerMinute < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_budget_per_minute"),
+			"Invalid PasteBin API Retry Budget Per Minute",
+			"The provider cannot create the PasteBin API client as the retry budget per minute is negative. "+
+				"Set retry_budget_per_minute to 0 or leave it empty to disable the retry budget.",
+		)
+	}
+
+	// Paste content above 1MiB is streamed, unless configured otherwise.
+	maxInlineBytes := int64(1024 * 1024)
+	if !config.MaxInlineBytes.IsNull() {
+		maxInlineBytes = config.MaxInlineBytes.ValueInt64()
+	}
+	if maxInlineBytes < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_inline_bytes"),
+			"Invalid PasteBin API Max Inline Bytes",
+			"The provider cannot create the PasteBin API client as the max inline bytes is negative. "+
+				"Set max_inline_bytes to 0 to stream the content of every paste.",
+		)
+	}
+
+	// Pastes above 256KiB are pointed out during plan, unless configured
+	// otherwise.
+	largePasteWarnBytes := int64(256 * 1024)
+	if !config.LargePasteWarnBytes.IsNull() {
+		largePasteWarnBytes = config.LargePasteWarnBytes.ValueInt64()
+	}
+	if largePasteWarnBytes < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("large_paste_warn_bytes"),
+			"Invalid PasteBin API Large Paste Warn Bytes",
+			"The provider cannot create the PasteBin API client as the large paste warn bytes is negative. "+
+				"Set large_paste_warn_bytes to 0 to disable the warning.",
 		)
 	}
 
@@ -170,29 +883,244 @@ func (p *pastebinProvider) Configure(ctx context.Context, req provider.Configure
 	}
 
 	// Create a new PasteBin client using the configuration values
-	client := pastebin.New(*hostUrl, devKey, userKey)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create PasteBin API Client",
-			"An unexpected error occurred when creating the PasteBin API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"PasteBin Client Error: "+err.Error(),
+	ctx = tflog.NewSubsystem(ctx, apiLogSubsystem)
+	ctx = tflog.SubsystemSetField(ctx, apiLogSubsystem, "pastebin_host", hostUrl.String())
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Creating PasteBin client")
+	// Every attempt of a request is observed, including its retries
+	observers := []RequestObserver{p.observer}
+	if config.EnableRequestTiming.ValueBool() {
+		observers = append(observers, timingObserver{})
+	}
+	httpClient := &http.Client{
+		Transport: newUserAgentTransport(newRetryTransport(newObserverTransport(newLoggingTransport(baseTransport), observers...), int(maxRetries), retryMinDelay, newRetryBudget(int(retryBudgetPerMinute))), userAgent),
+		Timeout:   timeout,
+	}
+	client := newPastebinClient(pastebin.New(*hostUrl, devKey, userKey), httpClient, newRateLimiter(int(requestsPerMinute)), int(maxInlineBytes))
+	client.refreshUserKey = refreshUserKey
+	client.scrapingApiKey = scrapingApiKey
+	client.basePath = basePath
+
+	// Pastes are created concurrently, unless configured otherwise.
+	if config.StrictOrdering.ValueBool() {
+		client.createLock = make(chan struct{}, 1)
+	}
+
+	// Pastes that are read multiple times are only requested once, unless
+	// configured otherwise.
+	if config.CacheReads.IsNull() || config.CacheReads.ValueBool() {
+		client.readCache = newReadCache()
+	}
+
+	// Catch a misconfigured host before the first data source or resource
+	// uses it, if requested.
+	if config.VerifyConnection.ValueBool() {
+		if err := client.VerifyConnection(ctx); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Unable to Verify PasteBin API Connection",
+				"The provider could not verify that "+hostUrl.String()+" is reachable and speaks the PasteBin API. "+
+					"Ensure the host value or the PASTEBIN_HOST environment variable points at Pastebin or a Pastebin compatible instance, "+
+					"and that the configured keys are valid.\n\n"+
+					"PasteBin Client Error: "+err.Error()+apiErrorHint(err),
+			)
+			return
+		}
+	}
+
+	// Terraform configures the provider for a plan as well, so the connection
+	// is still verified when planning without api requests
+	if config.VerifyConnection.ValueBool() && config.SkipApiOnPlan.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("verify_connection"),
+			"PasteBin API Connection Verified On Plan",
+			"Both verify_connection and skip_api_on_plan are set. Terraform configures the provider for plans as well as applies, "+
+				"so the connection is still verified during a plan. Unset verify_connection to plan without requests to the PasteBin API.",
 		)
+	}
+
+	// A private default visibility fails on the first paste that uses it
+	if config.DefaultVisibility.ValueString() == "private" && userKey == "" {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("default_visibility"),
+			"Private Default Visibility Without PasteBin API User Key",
+			"The default visibility of pastes is private, but the provider is configured without a user key. "+
+				"Planning a paste without its own visibility will fail, as private pastes require an authenticated user. "+
+				"Set the user_key value in the provider configuration or use the PASTEBIN_USER_KEY environment variable.",
+		)
+	}
+
+	// The default expiration may be an alias, which is planned as its code
+	defaultExpire, _ := normalizeExpire(config.DefaultExpire.ValueString())
+
+	// Make the PasteBin client and defaults available during DataSource and
+	// Resource type Configure methods.
+	providerData := &pastebinProviderData{
+		Client:            client,
+		DefaultFormat:     config.DefaultFormat.ValueString(),
+		DefaultExpire:     defaultExpire,
+		DefaultVisibility: config.DefaultVisibility.ValueString(),
+
+		LargePasteWarnBytes: largePasteWarnBytes,
+		SkipApiOnPlan:       config.SkipApiOnPlan.ValueBool(),
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+}
+
+// warnOverriddenEnv adds a warning to the diagnostics if the environment
+// variable is set to another value than the configured attribute, which takes
+// precedence. The values are not part of the warning, as they may be secret.
+func warnOverriddenEnv(attribute path.Path, envVar, value string, diagnostics *diag.Diagnostics) {
+	envValue := os.Getenv(envVar)
+	if envValue == "" || value == "" || envValue == value {
 		return
 	}
+	diagnostics.AddAttributeWarning(
+		attribute,
+		"Conflicting PasteBin API Configuration",
+		"Both the "+attribute.String()+" value in the provider configuration and the "+envVar+" environment variable are set, but to different values. "+
+			"The provider uses the "+attribute.String()+" value, which takes precedence over the environment variable. "+
+			"Unset one of them to remove this warning.",
+	)
+}
 
-	// Make the PasteBin client available during DataSource and Resource
-	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+// readKeyFile returns the api key in the file with the given name, without
+// surrounding whitespace. An error is added to the diagnostics if the file
+// cannot be read or does not contain a key.
+func readKeyFile(attribute path.Path, name string, diagnostics *diag.Diagnostics) string {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			attribute,
+			"Unreadable PasteBin API Key File",
+			"The provider cannot create the PasteBin API client as the key file could not be read: "+err.Error(),
+		)
+		return ""
+	}
+
+	key := strings.TrimSpace(string(content))
+	if key == "" {
+		diagnostics.AddAttributeError(
+			attribute,
+			"Empty PasteBin API Key File",
+			"The provider cannot create the PasteBin API client as the key file "+name+" is empty. "+
+				"Ensure the file contains the key.",
+		)
+	}
+	return key
+}
+
+// pastebinCredentials is the content of a credentials file.
+type pastebinCredentials struct {
+	Host    string `json:"host"`
+	DevKey  string `json:"dev_key"`
+	UserKey string `json:"user_key"`
+}
+
+// readCredentialsFile returns the credentials in the JSON file with the given
+// name. An error is added to the diagnostics if the file cannot be read, is
+// not a single JSON object with only string values for host, dev_key and
+// user_key, or does not contain any of them.
+func readCredentialsFile(attribute path.Path, name string, diagnostics *diag.Diagnostics) pastebinCredentials {
+	var credentials pastebinCredentials
+	file, err := os.Open(name)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			attribute,
+			"Unreadable PasteBin API Credentials File",
+			"The provider cannot create the PasteBin API client as the credentials file could not be read: "+err.Error(),
+		)
+		return credentials
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&credentials)
+	if err == nil && decoder.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("unexpected content after the JSON object")
+	}
+	if err != nil {
+		diagnostics.AddAttributeError(
+			attribute,
+			"Invalid PasteBin API Credentials File",
+			"The provider cannot create the PasteBin API client as the credentials file "+name+" is not valid: "+err.Error()+". "+
+				`Ensure the file contains a single JSON object with the string values "host", "dev_key" and "user_key", each of which is optional.`,
+		)
+		return pastebinCredentials{}
+	}
+	if credentials == (pastebinCredentials{}) {
+		diagnostics.AddAttributeError(
+			attribute,
+			"Empty PasteBin API Credentials File",
+			"The provider cannot create the PasteBin API client as the credentials file "+name+" does not contain a host, dev_key or user_key. "+
+				"Ensure the file contains the credentials.",
+		)
+	}
+	return credentials
+}
+
+// missingUserKeyError returns the error for an operation that requires an
+// authenticated user while the provider is configured without a user key.
+func missingUserKeyError(operation string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Missing PasteBin API User Key",
+		operation+" requires an authenticated user, but the provider is configured without a user key. "+
+			"Set the user_key value in the provider configuration or use the PASTEBIN_USER_KEY environment variable. "+
+			"Public and unlisted pastes can be created as a guest without a user key.",
+	)
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *pastebinProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewPasteDataSource,
+		NewUserPastesDataSource,
+		NewUserDataSource,
+		NewRawPasteDataSource,
+		NewPasteCountDataSource,
+		NewTrendsDataSource,
+		NewPasteInfoDataSource,
+		NewSearchPastesDataSource,
+		NewFormatsDataSource,
+		NewConfigDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
 func (p *pastebinProvider) Resources(_ context.Context) []func() resource.Resource {
-	return nil
+	return []func() resource.Resource{
+		NewPasteResource,
+		NewCleanupResource,
+		NewFolderResource,
+		NewOrphanCleanupResource,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *pastebinProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewRawUrlFunction,
+		NewIsValidFormatFunction,
+		NewParsePasteUrlFunction,
+	}
+}
+
+// invalidHostUrl returns why the host url cannot be used as the base url of
+// the PasteBin API, or an empty string if it can. The api paths are joined to
+// the host, so it must not have a path, query or fragment of its own.
+func invalidHostUrl(hostUrl *url.URL) string {
+	switch {
+	case hostUrl.Scheme != "http" && hostUrl.Scheme != "https":
+		return "does not use the http or https scheme"
+	case hostUrl.Host == "":
+		return "does not contain a host name"
+	case hostUrl.Path != "" && hostUrl.Path != "/":
+		return "contains the path " + hostUrl.Path
+	case hostUrl.RawQuery != "" || hostUrl.ForceQuery:
+		return "contains a query"
+	case hostUrl.Fragment != "":
+		return "contains a fragment"
+	}
+	return ""
 }
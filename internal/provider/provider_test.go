@@ -4,10 +4,16 @@
 package provider
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"terraform-provider-pastebin/internal/pastebintest"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -15,7 +21,7 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"pastebin": providerserver.NewProtocol6WithError(New("test")()),
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -23,3 +29,1189 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// testValidateResourceConfig validates the configuration of a resource the same
+// way Terraform does during plan and returns the resulting diagnostics.
+// Attributes that are missing from the configuration are set to null.
+func testValidateResourceConfig(t *testing.T, typeName string, config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for resource %s", typeName)
+	}
+
+	resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   testDynamicValue(t, resourceSchema, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Diagnostics
+}
+
+// testValidateProviderConfig validates the configuration of the provider the
+// same way Terraform does during plan and returns the resulting diagnostics.
+// Attributes that are missing from the configuration are set to null.
+func testValidateProviderConfig(t *testing.T, config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{
+		Config: testDynamicValue(t, schemaResp.Provider, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Diagnostics
+}
+
+// testConfigureProvider configures the provider the same way Terraform does
+// before any operation and returns the resulting diagnostics. Attributes that
+// are missing from the configuration are set to null.
+func testConfigureProvider(t *testing.T, config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemaResp.Provider, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Diagnostics
+}
+
+// testPlanResourceCreate configures the provider, and validates and plans the
+// creation of the resource with the given configuration the same way
+// Terraform does during plan, and returns the resulting diagnostics.
+// Attributes that are missing from the configuration are set to null.
+func testPlanResourceCreate(t *testing.T, providerConfig map[string]tftypes.Value, typeName string, config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for resource %s", typeName)
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemaResp.Provider, providerConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(configureResp.Diagnostics) {
+		return configureResp.Diagnostics
+	}
+
+	// Terraform validates the configuration again with the configured provider
+	validateResp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   testDynamicValue(t, resourceSchema, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(validateResp.Diagnostics) {
+		return validateResp.Diagnostics
+	}
+
+	objectType := resourceSchema.ValueType()
+	null, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       &null,
+		ProposedNewState: testDynamicValue(t, resourceSchema, config),
+		Config:           testDynamicValue(t, resourceSchema, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(validateResp.Diagnostics, resp.Diagnostics...)
+}
+
+// testPlanResourceUpdate configures the provider and plans the update of the
+// resource with the given state to the given configuration the same way
+// Terraform does during plan, and returns the attributes that require the
+// resource to be replaced and the resulting diagnostics. The proposed new
+// state is the state with the configured attributes.
+func testPlanResourceUpdate(t *testing.T, providerConfig map[string]tftypes.Value, typeName string, state, config map[string]tftypes.Value) ([]*tftypes.AttributePath, []*tfprotov6.Diagnostic) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for resource %s", typeName)
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemaResp.Provider, providerConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(configureResp.Diagnostics) {
+		return nil, configureResp.Diagnostics
+	}
+
+	proposed := map[string]tftypes.Value{}
+	for name, value := range state {
+		proposed[name] = value
+	}
+	for name, value := range config {
+		proposed[name] = value
+	}
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       testDynamicValue(t, resourceSchema, state),
+		ProposedNewState: testDynamicValue(t, resourceSchema, proposed),
+		Config:           testDynamicValue(t, resourceSchema, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+// testApplyResourceCreate configures the provider, and plans and applies the
+// creation of the resource with the given configuration the same way
+// Terraform does during apply, and returns the attributes of the new state
+// and the resulting diagnostics. Attributes that are missing from the
+// configuration are set to null.
+func testApplyResourceCreate(t *testing.T, providerConfig map[string]tftypes.Value, typeName string, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for resource %s", typeName)
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemaResp.Provider, providerConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(configureResp.Diagnostics) {
+		return nil, configureResp.Diagnostics
+	}
+
+	objectType := resourceSchema.ValueType()
+	null, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       &null,
+		ProposedNewState: testDynamicValue(t, resourceSchema, config),
+		Config:           testDynamicValue(t, resourceSchema, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(planResp.Diagnostics) {
+		return nil, planResp.Diagnostics
+	}
+
+	applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       typeName,
+		PriorState:     &null,
+		PlannedState:   planResp.PlannedState,
+		Config:         testDynamicValue(t, resourceSchema, config),
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applyResp.NewState == nil || testHasError(applyResp.Diagnostics) {
+		return nil, applyResp.Diagnostics
+	}
+	return testStateAttributes(t, resourceSchema, applyResp.NewState), applyResp.Diagnostics
+}
+
+// testApplyResourceUpdate configures the provider, and plans and applies the
+// in-place update of the resource with the given state to the given
+// configuration the same way Terraform does during apply, and returns the
+// attributes of the new state and the resulting diagnostics. The proposed new
+// state is the state with the configured attributes. The test fails if the
+// plan requires the resource to be replaced.
+func testApplyResourceUpdate(t *testing.T, providerConfig map[string]tftypes.Value, typeName string, state, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for resource %s", typeName)
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemaResp.Provider, providerConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(configureResp.Diagnostics) {
+		return nil, configureResp.Diagnostics
+	}
+
+	proposed := map[string]tftypes.Value{}
+	for name, value := range state {
+		proposed[name] = value
+	}
+	for name, value := range config {
+		proposed[name] = value
+	}
+	planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       testDynamicValue(t, resourceSchema, state),
+		ProposedNewState: testDynamicValue(t, resourceSchema, proposed),
+		Config:           testDynamicValue(t, resourceSchema, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(planResp.Diagnostics) {
+		return nil, planResp.Diagnostics
+	}
+	if len(planResp.RequiresReplace) > 0 {
+		t.Fatalf("expected an in-place update, got a replacement for %v", planResp.RequiresReplace)
+	}
+
+	applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       typeName,
+		PriorState:     testDynamicValue(t, resourceSchema, state),
+		PlannedState:   planResp.PlannedState,
+		Config:         testDynamicValue(t, resourceSchema, config),
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applyResp.NewState == nil || testHasError(applyResp.Diagnostics) {
+		return nil, applyResp.Diagnostics
+	}
+	return testStateAttributes(t, resourceSchema, applyResp.NewState), applyResp.Diagnostics
+}
+
+// testReadResource configures the provider and refreshes the resource with
+// the given state the same way Terraform does during plan, and returns the
+// attributes of the refreshed state, which are nil if the resource is gone,
+// and the resulting diagnostics.
+func testReadResource(t *testing.T, providerConfig map[string]tftypes.Value, typeName string, state map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for resource %s", typeName)
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemaResp.Provider, providerConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(configureResp.Diagnostics) {
+		return nil, configureResp.Diagnostics
+	}
+
+	resp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     typeName,
+		CurrentState: testDynamicValue(t, resourceSchema, state),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.NewState == nil || testHasError(resp.Diagnostics) {
+		return nil, resp.Diagnostics
+	}
+	newState, err := resp.NewState.Unmarshal(resourceSchema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newState.IsNull() {
+		return nil, resp.Diagnostics
+	}
+	return testStateAttributes(t, resourceSchema, resp.NewState), resp.Diagnostics
+}
+
+// testReadDataSource configures the provider and reads the data source with
+// the given configuration the same way Terraform does, and returns the
+// attributes of its state and the resulting diagnostics.
+func testReadDataSource(t *testing.T, providerConfig map[string]tftypes.Value, typeName string, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataSourceSchema, ok := schemaResp.DataSourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for data source %s", typeName)
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemaResp.Provider, providerConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(configureResp.Diagnostics) {
+		return nil, configureResp.Diagnostics
+	}
+
+	resp, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   testDynamicValue(t, dataSourceSchema, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.State == nil || testHasError(resp.Diagnostics) {
+		return nil, resp.Diagnostics
+	}
+	return testStateAttributes(t, dataSourceSchema, resp.State), resp.Diagnostics
+}
+
+// testFakeProviderConfig returns the provider configuration that connects to
+// the fake Pastebin server as its user, without retries.
+func testFakeProviderConfig(server *pastebintest.Server) map[string]tftypes.Value {
+	return map[string]tftypes.Value{
+		"host":        tftypes.NewValue(tftypes.String, server.URL),
+		"dev_key":     tftypes.NewValue(tftypes.String, server.DevKey),
+		"user_key":    tftypes.NewValue(tftypes.String, server.UserKey),
+		"max_retries": tftypes.NewValue(tftypes.Number, 0),
+	}
+}
+
+// testDestroyResource configures the provider and destroys the resource with
+// the given state the same way Terraform does during apply, and returns the
+// resulting diagnostics. Attributes that are missing from the configuration
+// or state are set to null.
+func testDestroyResource(t *testing.T, config map[string]tftypes.Value, typeName string, state map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for resource %s", typeName)
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemaResp.Provider, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(configureResp.Diagnostics) {
+		return configureResp.Diagnostics
+	}
+
+	objectType := resourceSchema.ValueType()
+	null, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     typeName,
+		PriorState:   testDynamicValue(t, resourceSchema, state),
+		PlannedState: &null,
+		Config:       &null,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Diagnostics
+}
+
+// testImportResourceState configures the provider and imports the resource
+// with the given import id the same way Terraform does during import, and
+// returns the attributes of the imported state and the diagnostics.
+func testImportResourceState(t *testing.T, providerConfig map[string]tftypes.Value, typeName, id string) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for resource %s", typeName)
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemaResp.Provider, providerConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testHasError(configureResp.Diagnostics) {
+		return nil, configureResp.Diagnostics
+	}
+
+	resp, err := server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: typeName,
+		ID:       id,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.ImportedResources) != 1 || testHasError(resp.Diagnostics) {
+		return nil, resp.Diagnostics
+	}
+	return testStateAttributes(t, resourceSchema, resp.ImportedResources[0].State), resp.Diagnostics
+}
+
+// testMoveResourceState moves the raw JSON state of the source resource to the
+// resource with the given type name the same way Terraform does for a moved
+// block, and returns the attributes of the moved state and the diagnostics.
+func testMoveResourceState(t *testing.T, sourceProviderAddress, sourceTypeName, sourceState, typeName string) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for resource %s", typeName)
+	}
+
+	resp, err := server.MoveResourceState(ctx, &tfprotov6.MoveResourceStateRequest{
+		SourceProviderAddress: sourceProviderAddress,
+		SourceTypeName:        sourceTypeName,
+		SourceState:           &tfprotov6.RawState{JSON: []byte(sourceState)},
+		TargetTypeName:        typeName,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.TargetState == nil {
+		return nil, resp.Diagnostics
+	}
+	return testStateAttributes(t, resourceSchema, resp.TargetState), resp.Diagnostics
+}
+
+// testUpgradeResourceState upgrades the raw JSON state of the resource from
+// the given schema version the same way Terraform does on refresh, and
+// returns the attributes of the upgraded state and the diagnostics.
+func testUpgradeResourceState(t *testing.T, typeName string, version int64, state string) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for resource %s", typeName)
+	}
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.UpgradedState == nil {
+		return nil, resp.Diagnostics
+	}
+	return testStateAttributes(t, resourceSchema, resp.UpgradedState), resp.Diagnostics
+}
+
+// testStateAttributes returns the attributes of the state of the schema.
+func testStateAttributes(t *testing.T, schema *tfprotov6.Schema, state *tfprotov6.DynamicValue) map[string]tftypes.Value {
+	t.Helper()
+
+	value, err := state.Unmarshal(schema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := map[string]tftypes.Value{}
+	if err := value.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	return attributes
+}
+
+// testCallFunction calls the function with the given arguments the same way
+// Terraform does and returns the result, or the error if the call failed.
+func testCallFunction(t *testing.T, name string, arguments ...tftypes.Value) (tftypes.Value, *tfprotov6.FunctionError) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dynamicArguments := make([]*tfprotov6.DynamicValue, len(arguments))
+	for i, argument := range arguments {
+		dynamicArgument, err := tfprotov6.NewDynamicValue(argument.Type(), argument)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dynamicArguments[i] = &dynamicArgument
+	}
+
+	resp, err := server.CallFunction(ctx, &tfprotov6.CallFunctionRequest{
+		Name:      name,
+		Arguments: dynamicArguments,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+
+	functionsResp, err := server.GetFunctions(ctx, &tfprotov6.GetFunctionsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := resp.Result.Unmarshal(functionsResp.Functions[name].Return.Type)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result, nil
+}
+
+// testDynamicValue creates a value of the schema from the given attributes,
+// setting the attributes that are missing to null.
+func testDynamicValue(t *testing.T, schema *tfprotov6.Schema, attributes map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	objectType := schema.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		value, ok := attributes[name]
+		if !ok {
+			value = tftypes.NewValue(attributeType, nil)
+		}
+		values[name] = value
+	}
+	dynamicValue, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dynamicValue
+}
+
+// testHasError returns whether any of the diagnostics is an error.
+func testHasError(diagnostics []*tfprotov6.Diagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func TestProviderSchema(t *testing.T) {
+	server, err := testAccProtoV6ProviderFactories["pastebin"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Terraform rejects the provider if any of its schemas is invalid
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range schemaResp.Diagnostics {
+		t.Errorf("unexpected diagnostic %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+}
+
+func TestProviderValidateConfig(t *testing.T) {
+	t.Setenv("PASTEBIN_DEV_KEY", "")
+
+	testCases := map[string]struct {
+		config    map[string]tftypes.Value
+		expectErr bool
+	}{
+		"empty": {
+			config: map[string]tftypes.Value{},
+		},
+		"defaults": {
+			config: map[string]tftypes.Value{
+				"default_format":     tftypes.NewValue(tftypes.String, "yaml"),
+				"default_expire":     tftypes.NewValue(tftypes.String, "1W"),
+				"default_visibility": tftypes.NewValue(tftypes.String, "private"),
+			},
+		},
+		"empty host": {
+			config: map[string]tftypes.Value{
+				"host": tftypes.NewValue(tftypes.String, ""),
+			},
+		},
+		"user key with dev key": {
+			config: map[string]tftypes.Value{
+				"dev_key":  tftypes.NewValue(tftypes.String, "dev"),
+				"user_key": tftypes.NewValue(tftypes.String, "user"),
+			},
+		},
+		"user key without dev key": {
+			config: map[string]tftypes.Value{
+				"user_key": tftypes.NewValue(tftypes.String, "user"),
+			},
+			expectErr: true,
+		},
+		"user key with credentials file": {
+			config: map[string]tftypes.Value{
+				"credentials_file": tftypes.NewValue(tftypes.String, "credentials.json"),
+				"user_key":         tftypes.NewValue(tftypes.String, "user"),
+			},
+		},
+		"user key with empty dev key": {
+			config: map[string]tftypes.Value{
+				"dev_key":  tftypes.NewValue(tftypes.String, ""),
+				"user_key": tftypes.NewValue(tftypes.String, "user"),
+			},
+			expectErr: true,
+		},
+		"invalid default format": {
+			config: map[string]tftypes.Value{
+				"default_format": tftypes.NewValue(tftypes.String, "yml"),
+			},
+			expectErr: true,
+		},
+		"invalid default expire": {
+			config: map[string]tftypes.Value{
+				"default_expire": tftypes.NewValue(tftypes.String, "2 days"),
+			},
+			expectErr: true,
+		},
+		"user key command without dev key": {
+			config: map[string]tftypes.Value{
+				"user_key_command": testUserKeyCommand("echo", "user"),
+			},
+			expectErr: true,
+		},
+		"invalid default visibility": {
+			config: map[string]tftypes.Value{
+				"default_visibility": tftypes.NewValue(tftypes.String, "hidden"),
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diagnostics := testValidateProviderConfig(t, testCase.config)
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+		})
+	}
+}
+
+// testUserKeyCommand returns a user_key_command value of the command.
+func testUserKeyCommand(command ...string) tftypes.Value {
+	values := make([]tftypes.Value, len(command))
+	for i, argument := range command {
+		values[i] = tftypes.NewValue(tftypes.String, argument)
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+}
+
+func TestProviderConfigure(t *testing.T) {
+	t.Setenv("PASTEBIN_HOST", "")
+	t.Setenv("PASTEBIN_DEV_KEY", "")
+	t.Setenv("PASTEBIN_USER_KEY", "")
+
+	keyFile := filepath.Join(t.TempDir(), "user_key")
+	if err := os.WriteFile(keyFile, []byte("user\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyKeyFile := filepath.Join(t.TempDir(), "empty")
+	if err := os.WriteFile(emptyKeyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		config    map[string]tftypes.Value
+		expectErr bool
+	}{
+		"authenticated": {
+			config: map[string]tftypes.Value{
+				"dev_key":  tftypes.NewValue(tftypes.String, "dev"),
+				"user_key": tftypes.NewValue(tftypes.String, "user"),
+			},
+		},
+		"guest": {
+			config: map[string]tftypes.Value{
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			},
+		},
+		"user key file": {
+			config: map[string]tftypes.Value{
+				"dev_key":       tftypes.NewValue(tftypes.String, "dev"),
+				"user_key_file": tftypes.NewValue(tftypes.String, keyFile),
+			},
+		},
+		"dev key file": {
+			config: map[string]tftypes.Value{
+				"dev_key_file": tftypes.NewValue(tftypes.String, keyFile),
+			},
+		},
+		"empty user key file": {
+			config: map[string]tftypes.Value{
+				"dev_key":       tftypes.NewValue(tftypes.String, "dev"),
+				"user_key_file": tftypes.NewValue(tftypes.String, emptyKeyFile),
+			},
+			expectErr: true,
+		},
+		"missing dev key file": {
+			config: map[string]tftypes.Value{
+				"dev_key_file": tftypes.NewValue(tftypes.String, "missing"),
+			},
+			expectErr: true,
+		},
+		"invalid ca cert file": {
+			config: map[string]tftypes.Value{
+				"dev_key":      tftypes.NewValue(tftypes.String, "dev"),
+				"ca_cert_file": tftypes.NewValue(tftypes.String, "provider_test.go"),
+			},
+			expectErr: true,
+		},
+		"missing ca cert file": {
+			config: map[string]tftypes.Value{
+				"dev_key":      tftypes.NewValue(tftypes.String, "dev"),
+				"ca_cert_file": tftypes.NewValue(tftypes.String, "missing.pem"),
+			},
+			expectErr: true,
+		},
+		"missing dev key": {
+			config: map[string]tftypes.Value{
+				"user_key": tftypes.NewValue(tftypes.String, "user"),
+			},
+			expectErr: true,
+		},
+		"user key command": {
+			config: map[string]tftypes.Value{
+				"dev_key":          tftypes.NewValue(tftypes.String, "dev"),
+				"user_key_command": testUserKeyCommand("echo", "user"),
+			},
+		},
+		"missing user key command": {
+			config: map[string]tftypes.Value{
+				"dev_key":          tftypes.NewValue(tftypes.String, "dev"),
+				"user_key_command": testUserKeyCommand("pastebin-user-key-command-that-does-not-exist"),
+			},
+			expectErr: true,
+		},
+		"empty user key command output": {
+			config: map[string]tftypes.Value{
+				"dev_key":          tftypes.NewValue(tftypes.String, "dev"),
+				"user_key_command": testUserKeyCommand("true"),
+			},
+			expectErr: true,
+		},
+		"host with trailing slash": {
+			config: map[string]tftypes.Value{
+				"host":    tftypes.NewValue(tftypes.String, "https://pastebin.example.com/"),
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			},
+		},
+		"file host": {
+			config: map[string]tftypes.Value{
+				"host":    tftypes.NewValue(tftypes.String, "file:///etc/pastebin"),
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			},
+			expectErr: true,
+		},
+		"ftp host": {
+			config: map[string]tftypes.Value{
+				"host":    tftypes.NewValue(tftypes.String, "ftp://pastebin.example.com"),
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			},
+			expectErr: true,
+		},
+		"bare word host": {
+			config: map[string]tftypes.Value{
+				"host":    tftypes.NewValue(tftypes.String, "pastebin"),
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			},
+			expectErr: true,
+		},
+		"host with path": {
+			config: map[string]tftypes.Value{
+				"host":    tftypes.NewValue(tftypes.String, "https://pastebin.example.com/api"),
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			},
+			expectErr: true,
+		},
+		"retry budget": {
+			config: map[string]tftypes.Value{
+				"dev_key":                 tftypes.NewValue(tftypes.String, "dev"),
+				"retry_budget_per_minute": tftypes.NewValue(tftypes.Number, 10),
+			},
+		},
+		"negative retry budget": {
+			config: map[string]tftypes.Value{
+				"dev_key":                 tftypes.NewValue(tftypes.String, "dev"),
+				"retry_budget_per_minute": tftypes.NewValue(tftypes.Number, -1),
+			},
+			expectErr: true,
+		},
+		"negative large paste warn bytes": {
+			config: map[string]tftypes.Value{
+				"dev_key":                tftypes.NewValue(tftypes.String, "dev"),
+				"large_paste_warn_bytes": tftypes.NewValue(tftypes.Number, -1),
+			},
+			expectErr: true,
+		},
+		"skip api on plan": {
+			config: map[string]tftypes.Value{
+				"dev_key":          tftypes.NewValue(tftypes.String, "dev"),
+				"skip_api_on_plan": tftypes.NewValue(tftypes.Bool, true),
+			},
+		},
+		"force http1": {
+			config: map[string]tftypes.Value{
+				"dev_key":     tftypes.NewValue(tftypes.String, "dev"),
+				"force_http1": tftypes.NewValue(tftypes.Bool, true),
+			},
+		},
+		"strict ordering": {
+			config: map[string]tftypes.Value{
+				"dev_key":         tftypes.NewValue(tftypes.String, "dev"),
+				"strict_ordering": tftypes.NewValue(tftypes.Bool, true),
+			},
+		},
+		"request timing": {
+			config: map[string]tftypes.Value{
+				"dev_key":               tftypes.NewValue(tftypes.String, "dev"),
+				"enable_request_timing": tftypes.NewValue(tftypes.Bool, true),
+			},
+		},
+		"explicit host": {
+			config: map[string]tftypes.Value{
+				"host":                  tftypes.NewValue(tftypes.String, "https://pastebin.example.com"),
+				"dev_key":               tftypes.NewValue(tftypes.String, "dev"),
+				"require_explicit_host": tftypes.NewValue(tftypes.Bool, true),
+			},
+		},
+		"missing explicit host": {
+			config: map[string]tftypes.Value{
+				"dev_key":               tftypes.NewValue(tftypes.String, "dev"),
+				"require_explicit_host": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expectErr: true,
+		},
+		"base path": {
+			config: map[string]tftypes.Value{
+				"host":      tftypes.NewValue(tftypes.String, "https://pastebin.example.com"),
+				"base_path": tftypes.NewValue(tftypes.String, "/pastebin/"),
+				"dev_key":   tftypes.NewValue(tftypes.String, "dev"),
+			},
+		},
+		"base path with host": {
+			config: map[string]tftypes.Value{
+				"base_path": tftypes.NewValue(tftypes.String, "https://pastebin.example.com/pastebin"),
+				"dev_key":   tftypes.NewValue(tftypes.String, "dev"),
+			},
+			expectErr: true,
+		},
+		"base path with query": {
+			config: map[string]tftypes.Value{
+				"base_path": tftypes.NewValue(tftypes.String, "/pastebin?debug=1"),
+				"dev_key":   tftypes.NewValue(tftypes.String, "dev"),
+			},
+			expectErr: true,
+		},
+		"host with query": {
+			config: map[string]tftypes.Value{
+				"host":    tftypes.NewValue(tftypes.String, "https://pastebin.example.com?debug=1"),
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diagnostics := testConfigureProvider(t, testCase.config)
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureConflictingEnv(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "dev_key")
+	if err := os.WriteFile(keyFile, []byte("dev\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		env         map[string]string
+		config      map[string]tftypes.Value
+		expectWarns []string
+	}{
+		"same values": {
+			env: map[string]string{"PASTEBIN_DEV_KEY": "dev", "PASTEBIN_USER_KEY": "user"},
+			config: map[string]tftypes.Value{
+				"dev_key":  tftypes.NewValue(tftypes.String, "dev"),
+				"user_key": tftypes.NewValue(tftypes.String, "user"),
+			},
+		},
+		"environment only": {
+			env: map[string]string{"PASTEBIN_DEV_KEY": "dev", "PASTEBIN_HOST": "https://pastebin.example.com"},
+		},
+		"different values": {
+			env: map[string]string{"PASTEBIN_HOST": "https://pastebin.example.com", "PASTEBIN_DEV_KEY": "env-dev", "PASTEBIN_USER_KEY": "env-user"},
+			config: map[string]tftypes.Value{
+				"host":     tftypes.NewValue(tftypes.String, "https://pastebin.com"),
+				"dev_key":  tftypes.NewValue(tftypes.String, "dev"),
+				"user_key": tftypes.NewValue(tftypes.String, "user"),
+			},
+			expectWarns: []string{"host", "dev_key", "user_key"},
+		},
+		"different key file": {
+			env: map[string]string{"PASTEBIN_DEV_KEY": "env-dev"},
+			config: map[string]tftypes.Value{
+				"dev_key_file": tftypes.NewValue(tftypes.String, keyFile),
+			},
+			expectWarns: []string{"dev_key_file"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, envVar := range []string{"PASTEBIN_HOST", "PASTEBIN_DEV_KEY", "PASTEBIN_USER_KEY"} {
+				t.Setenv(envVar, testCase.env[envVar])
+			}
+
+			diagnostics := testConfigureProvider(t, testCase.config)
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			var expected, warned []string
+			for _, attribute := range testCase.expectWarns {
+				expected = append(expected, tftypes.NewAttributePath().WithAttributeName(attribute).String())
+			}
+			for _, diagnostic := range diagnostics {
+				if diagnostic.Summary == "Conflicting PasteBin API Configuration" {
+					warned = append(warned, diagnostic.Attribute.String())
+					if strings.Contains(diagnostic.Detail, "env-") {
+						t.Errorf("expected the values not to be part of the warning, got %q", diagnostic.Detail)
+					}
+				}
+			}
+			if strings.Join(warned, ",") != strings.Join(expected, ",") {
+				t.Errorf("expected warnings for %v, got %v", expected, warned)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureCredentialsFile(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+
+	testCases := map[string]struct {
+		env      map[string]string
+		content  string
+		config   map[string]tftypes.Value
+		expected string
+	}{
+		"credentials file": {
+			content: `{"host": "` + server.URL + `", "dev_key": "dev", "user_key": "user"}`,
+		},
+		"configuration takes precedence": {
+			content: `{"host": "` + server.URL + `", "dev_key": "wrong", "user_key": "user"}`,
+			config: map[string]tftypes.Value{
+				"dev_key": tftypes.NewValue(tftypes.String, "dev"),
+			},
+		},
+		"takes precedence over environment": {
+			env:     map[string]string{"PASTEBIN_HOST": "https://pastebin.example.com", "PASTEBIN_DEV_KEY": "wrong"},
+			content: `{"host": "` + server.URL + `", "dev_key": "dev"}`,
+			config: map[string]tftypes.Value{
+				"user_key": tftypes.NewValue(tftypes.String, "user"),
+			},
+		},
+		"missing file": {
+			expected: "Unreadable PasteBin API Credentials File",
+		},
+		"malformed json": {
+			content:  `{"dev_key": "dev"`,
+			expected: "Invalid PasteBin API Credentials File",
+		},
+		"unknown field": {
+			content:  `{"dev_key": "dev", "api_key": "dev"}`,
+			expected: "Invalid PasteBin API Credentials File",
+		},
+		"non string value": {
+			content:  `{"dev_key": 42}`,
+			expected: "Invalid PasteBin API Credentials File",
+		},
+		"multiple objects": {
+			content:  `{"dev_key": "dev"} {"user_key": "user"}`,
+			expected: "Invalid PasteBin API Credentials File",
+		},
+		"empty object": {
+			content:  `{}`,
+			expected: "Empty PasteBin API Credentials File",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, envVar := range []string{"PASTEBIN_HOST", "PASTEBIN_DEV_KEY", "PASTEBIN_USER_KEY"} {
+				t.Setenv(envVar, testCase.env[envVar])
+			}
+			credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+			if testCase.content != "" {
+				if err := os.WriteFile(credentialsFile, []byte(testCase.content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			providerConfig := map[string]tftypes.Value{
+				"credentials_file": tftypes.NewValue(tftypes.String, credentialsFile),
+				"max_retries":      tftypes.NewValue(tftypes.Number, 0),
+			}
+			for attribute, value := range testCase.config {
+				providerConfig[attribute] = value
+			}
+
+			if testCase.expected != "" {
+				diagnostics := testConfigureProvider(t, providerConfig)
+				if !testHasError(diagnostics) || diagnostics[0].Summary != testCase.expected {
+					t.Errorf("expected error %q, got %v", testCase.expected, diagnostics)
+				}
+				return
+			}
+
+			// The user details are only returned for the host and keys of the fake server
+			attributes, diagnostics := testReadDataSource(t, providerConfig, "pastebin_user", map[string]tftypes.Value{})
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			if !attributes["username"].Equal(tftypes.NewValue(tftypes.String, server.UserName)) {
+				t.Errorf("expected username %q, got %v", server.UserName, attributes["username"])
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces out calls evenly so that no more than a fixed number of
+// calls per minute are let through. It behaves like a rate.Limiter of
+// golang.org/x/time/rate with a burst of 1, which is not used as that module
+// is not a dependency of the provider, and this is the only limiter it needs.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter creates a new rateLimiter that lets through requestsPerMinute
+// calls per minute, or nil if requestsPerMinute is not positive. A nil
+// rateLimiter does not limit any calls.
+func newRateLimiter(requestsPerMinute int) *rateLimiter {
+	if requestsPerMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		interval: time.Minute / time.Duration(requestsPerMinute),
+	}
+}
+
+// Wait blocks until the next call is allowed, or returns the error of the
+// context if it is done before then. A call that gives up returns its slot if
+// no later call reserved a slot after it.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	// Reserve the next available slot
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	reserved := l.next
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		if l.next.Equal(reserved) {
+			l.next = l.next.Add(-l.interval)
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSpacesOutCalls(t *testing.T) {
+	limiter := newRateLimiter(1200)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected 3 calls at 1200 per minute to take at least 100ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterConcurrentCalls(t *testing.T) {
+	limiter := newRateLimiter(1200)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected 5 concurrent calls at 1200 per minute to take at least 200ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterReturnsCancelledSlot(t *testing.T) {
+	limiter := newRateLimiter(600)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+
+	// The next call gets the slot of the cancelled call, 100ms after the first
+	start := time.Now()
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("expected the cancelled slot to be reused, waited %s", elapsed)
+	}
+}
+
+func TestRateLimiterRespectsContext(t *testing.T) {
+	limiter := newRateLimiter(1)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	limiter := newRateLimiter(0)
+	if limiter != nil {
+		t.Fatal("expected no rate limiter")
+	}
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &rawPasteDataSource{}
+	_ datasource.DataSourceWithConfigure = &rawPasteDataSource{}
+)
+
+// NewRawPasteDataSource is a helper function to simplify the provider implementation.
+func NewRawPasteDataSource() datasource.DataSource {
+	return &rawPasteDataSource{}
+}
+
+// rawPasteDataSource is the data source implementation.
+type rawPasteDataSource struct {
+	client *pastebinClient
+}
+
+// rawPasteDataSourceModel maps the data source schema data.
+type rawPasteDataSourceModel struct {
+	Key     types.String `tfsdk:"key"`
+	Content types.String `tfsdk:"content"`
+}
+
+// Metadata returns the data source type name.
+func (d *rawPasteDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_raw"
+}
+
+// Schema defines the schema for the data source.
+func (d *rawPasteDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			// The key is extracted from a paste url, so a url can be used as key.
+			"key": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					pasteKeyOrUrl(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *rawPasteDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *rawPasteDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state rawPasteDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The key was already validated, unless it was unknown during validation
+	pasteKey, err := parsePasteKey(state.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key"),
+			"Invalid Pastebin Paste Key",
+			"The key must be a paste key such as abcd1234 or a paste url such as https://pastebin.com/abcd1234, but the "+err.Error()+".",
+		)
+		return
+	}
+
+	// Private pastes can only be read by their owner, while public and
+	// unlisted pastes of other users can only be read anonymously, or through
+	// the scraping api if configured
+	var content string
+	if d.client.scrapingApiKey != "" {
+		content, err = d.client.ScrapePaste(ctx, pasteKey)
+		if errors.Is(err, errPasteNotFound) && d.client.client.UserKey != "" {
+			content, err = d.client.GetPaste(ctx, pasteKey)
+		}
+	} else {
+		content, err = d.client.GetPaste(ctx, pasteKey)
+		if errors.Is(err, errPasteNotFound) && d.client.client.UserKey != "" {
+			content, err = d.client.GetPublicPaste(ctx, pasteKey)
+		}
+	}
+	if errors.Is(err, errPasteNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key"),
+			"Pastebin Paste Not Found",
+			"The paste with key "+pasteKey+" does not exist, has expired, or is private to another user.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(buildDiagnostic(classifyError(err), fmt.Errorf("could not read the raw content of paste %s: %w", pasteKey, err)))
+		return
+	}
+	state.Content = types.StringValue(content)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-pastebin/internal/pastebintest"
+)
+
+func TestRawPasteDataSourceReadScraping(t *testing.T) {
+	testCases := map[string]struct {
+		scrapingApiKey string
+		paste          pastebintest.Paste
+		expectErr      bool
+		expectScrape   int
+	}{
+		"unlisted paste": {
+			scrapingApiKey: "scraping",
+			paste:          pastebintest.Paste{Content: "Hello from Pastebin.", Private: "1"},
+			expectScrape:   1,
+		},
+		"private paste of the user": {
+			scrapingApiKey: "scraping",
+			paste:          pastebintest.Paste{Content: "Hello from Pastebin.", Private: "2", Owner: "user"},
+			expectScrape:   1,
+		},
+		"private paste of another user": {
+			scrapingApiKey: "scraping",
+			paste:          pastebintest.Paste{Content: "Hello from Pastebin.", Private: "2", Owner: "other"},
+			expectErr:      true,
+			expectScrape:   1,
+		},
+		"access denied": {
+			scrapingApiKey: "invalid",
+			paste:          pastebintest.Paste{Content: "Hello from Pastebin.", Private: "1"},
+			expectErr:      true,
+			expectScrape:   1,
+		},
+		"without scraping api key": {
+			paste: pastebintest.Paste{Content: "Hello from Pastebin.", Private: "1"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			key := server.AddPaste(testCase.paste)
+			providerConfig := testFakeProviderConfig(server)
+			if testCase.scrapingApiKey != "" {
+				providerConfig["scraping_api_key"] = tftypes.NewValue(tftypes.String, testCase.scrapingApiKey)
+			}
+
+			attributes, diagnostics := testReadDataSource(t, providerConfig, "pastebin_raw", map[string]tftypes.Value{
+				"key": tftypes.NewValue(tftypes.String, key),
+			})
+			if testHasError(diagnostics) != testCase.expectErr {
+				t.Fatalf("expected error %t, got %v", testCase.expectErr, diagnostics)
+			}
+			if !testCase.expectErr && !attributes["content"].Equal(tftypes.NewValue(tftypes.String, testCase.paste.Content)) {
+				t.Errorf("expected content %q, got %v", testCase.paste.Content, attributes["content"])
+			}
+			if requests := server.Requests("scrape_item"); requests != testCase.expectScrape {
+				t.Errorf("expected %d scraping requests, got %d", testCase.expectScrape, requests)
+			}
+		})
+	}
+}
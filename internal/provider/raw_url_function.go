@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &rawUrlFunction{}
+)
+
+// NewRawUrlFunction is a helper function to simplify the provider implementation.
+func NewRawUrlFunction() function.Function {
+	return &rawUrlFunction{}
+}
+
+// rawUrlFunction is the function implementation.
+type rawUrlFunction struct{}
+
+// Metadata returns the function name.
+func (f *rawUrlFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "raw_url"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *rawUrlFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the url of the raw content of a paste",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name: "key",
+			},
+		},
+		// The host is optional, and defaults to https://pastebin.com.
+		VariadicParameter: function.StringParameter{
+			Name: "host",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run returns the raw url of the paste with the given key.
+func (f *rawUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var key string
+	var hosts []string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &key, &hosts))
+	if resp.Error != nil {
+		return
+	}
+
+	if key == "" {
+		resp.Error = function.NewArgumentFuncError(0, "The paste key must not be empty.")
+		return
+	}
+	if len(hosts) > 1 {
+		resp.Error = function.NewArgumentFuncError(1, "At most one host can be given.")
+		return
+	}
+
+	host := defaultHost
+	if len(hosts) == 1 {
+		host = hosts[0]
+	}
+	hostUrl, err := url.Parse(host)
+	if err != nil || hostUrl.Scheme == "" || hostUrl.Host == "" {
+		resp.Error = function.NewArgumentFuncError(1, "The host must be a valid url such as 'https://pastebin.com', got: "+host)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, rawPasteUrl(*hostUrl, key)))
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRawUrlFunction(t *testing.T) {
+	testCases := map[string]struct {
+		arguments []tftypes.Value
+		expected  string
+		expectErr bool
+	}{
+		"default host": {
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "abcd1234"),
+			},
+			expected: "https://pastebin.com/raw/abcd1234",
+		},
+		"host": {
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "abcd1234"),
+				tftypes.NewValue(tftypes.String, "http://localhost:8080"),
+			},
+			expected: "http://localhost:8080/raw/abcd1234",
+		},
+		"invalid host": {
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "abcd1234"),
+				tftypes.NewValue(tftypes.String, "pastebin.com"),
+			},
+			expectErr: true,
+		},
+		"multiple hosts": {
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "abcd1234"),
+				tftypes.NewValue(tftypes.String, "https://pastebin.com"),
+				tftypes.NewValue(tftypes.String, "https://pastebin.com"),
+			},
+			expectErr: true,
+		},
+		"empty key": {
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, ""),
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := testCallFunction(t, "raw_url", testCase.arguments...)
+			if (funcErr != nil) != testCase.expectErr {
+				t.Fatalf("expected error %t, got %v", testCase.expectErr, funcErr)
+			}
+			if testCase.expectErr {
+				return
+			}
+			if expected := tftypes.NewValue(tftypes.String, testCase.expected); !result.Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, result)
+			}
+		})
+	}
+}
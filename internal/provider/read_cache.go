@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"sync"
+)
+
+// readCache caches the raw content of pastes by their key, so a paste that is
+// read by multiple data sources and resources is only requested once. It is
+// shared through the client of a provider instance, which Terraform starts
+// for a single operation, so cached content never outlives that operation.
+type readCache struct {
+	mu      sync.Mutex
+	entries map[readCacheKey]*readCacheEntry
+}
+
+// readCacheKey identifies a read of a paste. The content of a paste may differ
+// between the charsets it is read in, and between the authenticated api and
+// the public raw endpoint, so those reads are cached separately.
+type readCacheKey struct {
+	pasteKey string
+	charset  string
+	public   bool
+}
+
+// readCacheEntry is the content of a paste, which is done once the read of
+// the paste finished.
+type readCacheEntry struct {
+	done    chan struct{}
+	content string
+	err     error
+}
+
+// newReadCache creates a new empty readCache.
+func newReadCache() *readCache {
+	return &readCache{
+		entries: map[readCacheKey]*readCacheEntry{},
+	}
+}
+
+// Get returns the cached content of the read with the given key, or reads it
+// with read if it is not cached yet. Concurrent calls for the same key share a
+// single read. Failed reads are not cached. A nil readCache always reads the
+// paste.
+func (c *readCache) Get(ctx context.Context, key readCacheKey, read func() (string, error)) (string, error) {
+	if c == nil {
+		return read()
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &readCacheEntry{done: make(chan struct{})}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	// Wait for the read of another call
+	if ok {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-entry.done:
+			return entry.content, entry.err
+		}
+	}
+
+	entry.content, entry.err = read()
+	if entry.err != nil {
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+	}
+	close(entry.done)
+	return entry.content, entry.err
+}
+
+// Forget removes all reads of the paste with the given key from the cache,
+// such as when the paste is deleted.
+func (c *readCache) Forget(pasteKey string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	for key := range c.entries {
+		if key.pasteKey == pasteKey {
+			delete(c.entries, key)
+		}
+	}
+	c.mu.Unlock()
+}
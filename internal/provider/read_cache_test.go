@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestReadCacheSharesReads(t *testing.T) {
+	cache := newReadCache()
+	var reads atomic.Int32
+	release := make(chan struct{})
+	read := func() (string, error) {
+		reads.Add(1)
+		<-release
+		return "hello", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			content, err := cache.Get(context.Background(), readCacheKey{pasteKey: "abcd1234"}, read)
+			if err != nil || content != "hello" {
+				t.Errorf("expected content hello, got %q (%v)", content, err)
+			}
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	if reads.Load() != 1 {
+		t.Errorf("expected a single read, got %d", reads.Load())
+	}
+}
+
+func TestReadCacheForgetsFailedReads(t *testing.T) {
+	cache := newReadCache()
+	reads := 0
+	read := func() (string, error) {
+		reads++
+		if reads == 1 {
+			return "", errors.New("connection refused")
+		}
+		return "hello", nil
+	}
+
+	if _, err := cache.Get(context.Background(), readCacheKey{pasteKey: "abcd1234"}, read); err == nil {
+		t.Fatal("expected the first read to fail")
+	}
+	if content, err := cache.Get(context.Background(), readCacheKey{pasteKey: "abcd1234"}, read); err != nil || content != "hello" {
+		t.Errorf("expected the failed read to be retried, got %q (%v)", content, err)
+	}
+	_, _ = cache.Get(context.Background(), readCacheKey{pasteKey: "abcd1234"}, read)
+	if reads != 2 {
+		t.Errorf("expected 2 reads, got %d", reads)
+	}
+}
+
+func TestReadCacheForget(t *testing.T) {
+	cache := newReadCache()
+	reads := 0
+	read := func() (string, error) {
+		reads++
+		return "hello", nil
+	}
+
+	_, _ = cache.Get(context.Background(), readCacheKey{pasteKey: "abcd1234"}, read)
+	cache.Forget("abcd1234")
+	_, _ = cache.Get(context.Background(), readCacheKey{pasteKey: "abcd1234"}, read)
+	if reads != 2 {
+		t.Errorf("expected a forgotten paste to be read again, got %d reads", reads)
+	}
+}
+
+func TestReadCacheSeparatesReads(t *testing.T) {
+	cache := newReadCache()
+	keys := []readCacheKey{
+		{pasteKey: "abcd1234"},
+		{pasteKey: "abcd1234", charset: "iso-8859-1"},
+		{pasteKey: "abcd1234", public: true},
+	}
+	for _, key := range keys {
+		content, err := cache.Get(context.Background(), key, func() (string, error) {
+			return key.charset + fmt.Sprint(key.public), nil
+		})
+		if err != nil || content != key.charset+fmt.Sprint(key.public) {
+			t.Errorf("expected the content of read %v, got %q (%v)", key, content, err)
+		}
+	}
+
+	// Forgetting a paste forgets all of its reads
+	cache.Forget("abcd1234")
+	for _, key := range keys {
+		reads := 0
+		_, _ = cache.Get(context.Background(), key, func() (string, error) {
+			reads++
+			return "", nil
+		})
+		if reads != 1 {
+			t.Errorf("expected read %v to be forgotten", key)
+		}
+	}
+}
+
+func TestNilReadCache(t *testing.T) {
+	var cache *readCache
+	reads := 0
+	read := func() (string, error) {
+		reads++
+		return "hello", nil
+	}
+
+	_, _ = cache.Get(context.Background(), readCacheKey{pasteKey: "abcd1234"}, read)
+	_, _ = cache.Get(context.Background(), readCacheKey{pasteKey: "abcd1234"}, read)
+	cache.Forget("abcd1234")
+	if reads != 2 {
+		t.Errorf("expected a nil cache to read every time, got %d reads", reads)
+	}
+}
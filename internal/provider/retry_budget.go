@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"sync"
+	"time"
+)
+
+// retryBudget is a token bucket that limits the number of retries across all
+// requests, so a failing backend is not overloaded by the retries of many
+// concurrent requests. The bucket holds up to a minute of retries, and is
+// refilled continuously.
+type retryBudget struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	rate     float64
+	last     time.Time
+}
+
+// newRetryBudget creates a new retryBudget that allows retriesPerMinute
+// retries per minute, or nil if retriesPerMinute is not positive. A nil
+// retryBudget allows every retry.
+func newRetryBudget(retriesPerMinute int) *retryBudget {
+	if retriesPerMinute <= 0 {
+		return nil
+	}
+	return &retryBudget{
+		capacity: float64(retriesPerMinute),
+		tokens:   float64(retriesPerMinute),
+		rate:     float64(retriesPerMinute) / time.Minute.Seconds(),
+		last:     time.Now(),
+	}
+}
+
+// Take returns whether a retry is allowed, using up one retry of the budget
+// if it is. Unlike the rate limiter it never blocks, as a retry that is not
+// allowed should fail immediately.
+func (b *retryBudget) Take() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens = min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryBudgetRefills(t *testing.T) {
+	budget := newRetryBudget(60)
+	for i := 0; i < 60; i++ {
+		if !budget.Take() {
+			t.Fatalf("expected retry %d to be allowed", i+1)
+		}
+	}
+	if budget.Take() {
+		t.Fatal("expected the exhausted budget not to allow a retry")
+	}
+
+	// A budget of 60 per minute refills a retry every second
+	budget.last = budget.last.Add(-time.Second)
+	if !budget.Take() {
+		t.Error("expected the refilled budget to allow a retry")
+	}
+}
+
+func TestRetryBudgetDisabled(t *testing.T) {
+	budget := newRetryBudget(0)
+	if budget != nil {
+		t.Fatal("expected no retry budget")
+	}
+	if !budget.Take() {
+		t.Error("expected a disabled retry budget to allow every retry")
+	}
+}
+
+func TestRetryTransportFailsFastWhenBudgetExhausted(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// The budget is shared by the requests of the client, so the first
+	// request uses it up and the second request is not retried at all
+	client := &http.Client{
+		Transport: newRetryTransport(server.Client().Transport, 3, 100*time.Millisecond, newRetryBudget(2)),
+	}
+	for i, expectedAttempts := range []int{3, 1} {
+		attempts = 0
+		start := time.Now()
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusServiceUnavailable || attempts != expectedAttempts {
+			t.Errorf("expected status 503 after %d attempts on request %d, got status %d after %d attempts", expectedAttempts, i+1, resp.StatusCode, attempts)
+		}
+		if expectedAttempts == 1 && time.Since(start) >= 100*time.Millisecond {
+			t.Errorf("expected request %d to fail without waiting, took %s", i+1, time.Since(start))
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &searchPastesDataSource{}
+	_ datasource.DataSourceWithConfigure        = &searchPastesDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &searchPastesDataSource{}
+)
+
+// NewSearchPastesDataSource is a helper function to simplify the provider implementation.
+func NewSearchPastesDataSource() datasource.DataSource {
+	return &searchPastesDataSource{}
+}
+
+// searchPastesDataSource is the data source implementation.
+type searchPastesDataSource struct {
+	client *pastebinClient
+}
+
+// searchPastesDataSourceModel maps the data source schema data.
+type searchPastesDataSourceModel struct {
+	Query  types.String        `tfsdk:"query"`
+	Pastes []searchPastesModel `tfsdk:"pastes"`
+}
+
+// searchPastesModel maps the paste schema data.
+type searchPastesModel struct {
+	Key   types.String `tfsdk:"key"`
+	Title types.String `tfsdk:"title"`
+	Date  types.String `tfsdk:"date"`
+}
+
+// Metadata returns the data source type name.
+func (d *searchPastesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search_pastes"
+}
+
+// Schema defines the schema for the data source.
+func (d *searchPastesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringNotBlank(),
+				},
+			},
+			"pastes": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed: true,
+						},
+						"title": schema.StringAttribute{
+							Computed: true,
+						},
+						"date": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators returns the validators for the data source configuration.
+func (d *searchPastesDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		requiresUserKey(d.client, "Searching the pastes of a user"),
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *searchPastesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *searchPastesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state searchPastesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Searching pastes requires an authenticated user
+	if d.client.client.UserKey == "" {
+		resp.Diagnostics.Append(missingUserKeyError("Searching the pastes of a user"))
+		return
+	}
+
+	// Pastebin has no search api and the list api has no paging, so the
+	// maximum number of results is all that can be searched
+	pastes, err := d.client.ListPastes(ctx, maxListResults)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Pastebin Pastes",
+			"Could not list the pastes of the user: "+err.Error(),
+		)
+		return
+	}
+	if len(pastes) >= maxListResults {
+		resp.Diagnostics.AddWarning(
+			"Incomplete Pastebin Paste Search",
+			fmt.Sprintf("The user has at least %d pastes, which is the maximum number of pastes the PasteBin API lists. "+
+				"Pastes beyond this limit are not searched.", maxListResults),
+		)
+	}
+
+	// Map the pastes with a matching title to the model, which are none if
+	// nothing matches
+	query := strings.ToLower(state.Query.ValueString())
+	state.Pastes = []searchPastesModel{}
+	for _, paste := range pastes {
+		if !strings.Contains(strings.ToLower(paste.Title), query) {
+			continue
+		}
+		state.Pastes = append(state.Pastes, searchPastesModel{
+			Key:   types.StringValue(paste.Key),
+			Title: types.StringValue(paste.Title),
+			Date:  unixTimestamp(paste.Date),
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
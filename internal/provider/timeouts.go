@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeoutsAttributeTypes are the attribute types of the timeouts block, which
+// has a duration for each operation on a resource.
+var timeoutsAttributeTypes = map[string]attr.Type{
+	"create": types.StringType,
+	"read":   types.StringType,
+	"update": types.StringType,
+	"delete": types.StringType,
+}
+
+// timeoutsBlock returns the schema of the timeouts block, which follows the
+// timeouts block of the other Terraform providers. It has the attributes and
+// duration format of the block of terraform-plugin-framework-timeouts, which
+// is not used as that module is not a dependency of the provider. Switching
+// to it later keeps configurations and states compatible.
+func timeoutsBlock() schema.Block {
+	attributes := map[string]schema.Attribute{}
+	for operation := range timeoutsAttributeTypes {
+		attributes[operation] = schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringDuration(),
+			},
+		}
+	}
+	return schema.SingleNestedBlock{
+		Attributes: attributes,
+	}
+}
+
+// timeoutsNull returns a timeouts block that is not configured.
+func timeoutsNull() types.Object {
+	return types.ObjectNull(timeoutsAttributeTypes)
+}
+
+// operationTimeout returns the configured timeout of the operation, or the
+// default timeout if the timeouts block or the operation is not configured.
+func operationTimeout(timeouts types.Object, operation string, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	value, ok := timeouts.Attributes()[operation].(types.String)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return defaultTimeout, diags
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("timeouts").AtName(operation),
+			"Invalid Timeout",
+			"The "+operation+" timeout "+value.ValueString()+" is not a positive duration such as '30s' or '5m'.",
+		)
+		return defaultTimeout, diags
+	}
+	return timeout, diags
+}
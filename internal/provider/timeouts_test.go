@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOperationTimeout(t *testing.T) {
+	timeouts := types.ObjectValueMust(timeoutsAttributeTypes, map[string]attr.Value{
+		"create": types.StringValue("10m"),
+		"read":   types.StringNull(),
+		"update": types.StringUnknown(),
+		"delete": types.StringValue("soon"),
+	})
+
+	testCases := map[string]struct {
+		timeouts  types.Object
+		operation string
+		expected  time.Duration
+		expectErr bool
+	}{
+		"not configured": {timeouts: timeoutsNull(), operation: "create", expected: time.Minute},
+		"configured":     {timeouts: timeouts, operation: "create", expected: 10 * time.Minute},
+		"null":           {timeouts: timeouts, operation: "read", expected: time.Minute},
+		"unknown":        {timeouts: timeouts, operation: "update", expected: time.Minute},
+		"invalid":        {timeouts: timeouts, operation: "delete", expected: time.Minute, expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			timeout, diags := operationTimeout(testCase.timeouts, testCase.operation, time.Minute)
+			if diags.HasError() != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, diags)
+			}
+			if timeout != testCase.expected {
+				t.Errorf("expected timeout %s, got %s", testCase.expected, timeout)
+			}
+		})
+	}
+}
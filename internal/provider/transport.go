@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// disableHTTP2 makes the transport use HTTP/1.1 only. An empty TLSNextProto
+// map keeps the transport from negotiating HTTP/2 with the host, and the TLS
+// configuration must not offer it either.
+func disableHTTP2(transport *http.Transport) {
+	transport.ForceAttemptHTTP2 = false
+	transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	if transport.TLSClientConfig != nil {
+		transport.TLSClientConfig.NextProtos = []string{"http/1.1"}
+	}
+}
+
+// retryTransport is a http.RoundTripper that retries requests that failed
+// with a transient error, waiting with an exponential backoff in between.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minDelay   time.Duration
+	budget     *retryBudget
+}
+
+// newRetryTransport creates a new retryTransport that sends its requests
+// using the base transport. Retries are limited by the given retry budget,
+// which is shared by all requests.
+func newRetryTransport(base http.RoundTripper, maxRetries int, minDelay time.Duration, budget *retryBudget) *retryTransport {
+	return &retryTransport{
+		base:       base,
+		maxRetries: maxRetries,
+		minDelay:   minDelay,
+		budget:     budget,
+	}
+}
+
+// RoundTrip executes the request, retrying it on network errors, rate
+// limiting and server errors. A non-idempotent request is only retried when
+// rate limited, as it may have succeeded despite another failure. A
+// Retry-After header on a rate limited response takes precedence over the
+// exponential backoff.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotent := !isNonIdempotent(req.Context())
+	for attempt := 0; ; attempt++ {
+		// Every attempt needs a fresh copy of the request body, which is set
+		// on a copy as a RoundTripper must not modify the original request
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !isRetryable(resp, err, idempotent) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		// Once the retry budget is exhausted, failures are returned as is
+		if !t.budget.Take() {
+			tflog.Warn(req.Context(), "PasteBin API retry budget exhausted, not retrying the request", map[string]interface{}{
+				"url": req.URL.String(),
+			})
+			return resp, err
+		}
+
+		// Discard the failed response before trying again
+		delay := t.minDelay << attempt
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp, time.Now()); ok && resp.StatusCode == http.StatusTooManyRequests {
+				delay = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// isRetryable returns whether a request that resulted in the response or
+// error is worth retrying. A rate limited request was not processed, so only
+// that is worth retrying for a request that is not idempotent.
+func isRetryable(resp *http.Response, err error, idempotent bool) bool {
+	if err != nil {
+		return idempotent
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || (idempotent && resp.StatusCode >= 500)
+}
+
+// nonIdempotentKey is the context key that marks the requests made with the
+// context as not idempotent.
+type nonIdempotentKey struct{}
+
+// withNonIdempotent returns a copy of the context that marks the requests made
+// with it as not idempotent, such as creating a paste, so they are not retried
+// after a failure that may have happened after the request was processed.
+func withNonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nonIdempotentKey{}, true)
+}
+
+// isNonIdempotent returns whether the context marks the requests as not idempotent.
+func isNonIdempotent(ctx context.Context) bool {
+	nonIdempotent, _ := ctx.Value(nonIdempotentKey{}).(bool)
+	return nonIdempotent
+}
+
+// parseRetryAfter returns the delay requested by the Retry-After header of the
+// response, which is either a number of seconds or a http date.
+func parseRetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// userAgentTransport is a http.RoundTripper that sets the User-Agent header
+// on every request.
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+// newUserAgentTransport creates a new userAgentTransport that sends its
+// requests using the base transport.
+func newUserAgentTransport(base http.RoundTripper, userAgent string) *userAgentTransport {
+	return &userAgentTransport{
+		base:      base,
+		userAgent: userAgent,
+	}
+}
+
+// RoundTrip executes the request with the User-Agent header set.
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the original request
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
+
+// gzipTransport is a http.RoundTripper that requests gzip compressed
+// responses and decompresses them, which mostly reduces the bandwidth of
+// reading the raw content of large pastes.
+type gzipTransport struct {
+	base http.RoundTripper
+}
+
+// newGzipTransport creates a new gzipTransport that sends its requests using
+// the base transport, which should have its own compression disabled.
+func newGzipTransport(base http.RoundTripper) *gzipTransport {
+	return &gzipTransport{
+		base: base,
+	}
+}
+
+// RoundTrip executes the request with the Accept-Encoding header set, and
+// replaces the body of a gzip compressed response with its decompressed body.
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Leave requests that negotiate their own encoding as is
+	if req.Header.Get("Accept-Encoding") != "" || req.Header.Get("Range") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the original request
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Header.Get("Content-Encoding") != "gzip" {
+		return resp, err
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	// The length of the decompressed body is unknown, and the charset of the
+	// Content-Type still applies to the decompressed body
+	resp.Body = &gzipBody{reader: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// gzipBody is the decompressed body of a gzip compressed response.
+type gzipBody struct {
+	reader *gzip.Reader
+	body   io.ReadCloser
+}
+
+// Read reads the decompressed body.
+func (b *gzipBody) Read(p []byte) (int, error) {
+	return b.reader.Read(p)
+}
+
+// Close closes the compressed body.
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
+
+// apiLogSubsystem is the name of the logging subsystem of the api requests.
+const apiLogSubsystem = "pastebin_api"
+
+// redactedFormKeys are the form values that are never logged.
+var redactedFormKeys = []string{"api_dev_key", "api_user_key"}
+
+// sensitiveContentKey is the context key that marks the paste content of the
+// requests made with the context as sensitive.
+type sensitiveContentKey struct{}
+
+// withSensitiveContent returns a copy of the context that marks the paste
+// content of the requests made with it as sensitive, so it is not logged.
+func withSensitiveContent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sensitiveContentKey{}, true)
+}
+
+// isSensitiveContent returns whether the context marks the paste content as sensitive.
+func isSensitiveContent(ctx context.Context) bool {
+	sensitive, _ := ctx.Value(sensitiveContentKey{}).(bool)
+	return sensitive
+}
+
+// loggingTransport is a http.RoundTripper that logs every request and its
+// response at debug level.
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+// newLoggingTransport creates a new loggingTransport that sends its requests
+// using the base transport.
+func newLoggingTransport(base http.RoundTripper) *loggingTransport {
+	return &loggingTransport{
+		base: base,
+	}
+}
+
+// RoundTrip executes the request and logs its method, url, status and duration.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), apiLogSubsystem)
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.String(),
+	}
+	// Streamed bodies are not logged, as they are not buffered
+	if req.GetBody != nil && req.ContentLength >= 0 {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			keys := redactedFormKeys
+			if isSensitiveContent(ctx) {
+				keys = append([]string{"api_paste_code"}, keys...)
+			}
+			fields["body"] = redactFormBody(string(data), keys)
+		}
+	}
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Sending PasteBin API request", fields)
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields["duration"] = time.Since(start).String()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, apiLogSubsystem, "PasteBin API request failed", fields)
+		return resp, err
+	}
+	fields["status"] = resp.StatusCode
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Received PasteBin API response", fields)
+	return resp, nil
+}
+
+// redactFormBody replaces the values of the keys in a form encoded request
+// body, so the body can be logged.
+func redactFormBody(body string, keys []string) string {
+	data, err := url.ParseQuery(body)
+	if err != nil {
+		return "[unparseable body redacted]"
+	}
+	for _, key := range keys {
+		if data.Has(key) {
+			data.Set(key, "***")
+		}
+	}
+	return data.Encode()
+}
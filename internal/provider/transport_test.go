@@ -0,0 +1,369 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// newTestRetryClient creates a http client that retries its requests with
+// a negligible delay.
+func newTestRetryClient(server *httptest.Server, maxRetries int) *http.Client {
+	return &http.Client{
+		Transport: newRetryTransport(server.Client().Transport, maxRetries, time.Millisecond, nil),
+	}
+}
+
+func TestRetryTransportRetriesServerErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("api_option") != "paste" {
+			t.Errorf("expected the request body on attempt %d, got %v", attempts, r.PostForm)
+		}
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	resp, err := newTestRetryClient(server, 3).Post(server.URL, "application/x-www-form-urlencoded", strings.NewReader("api_option=paste"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || attempts != 3 {
+		t.Errorf("expected success after 3 attempts, got status %d after %d attempts", resp.StatusCode, attempts)
+	}
+}
+
+func TestRetryTransportNonIdempotent(t *testing.T) {
+	testCases := map[string]struct {
+		status   int
+		attempts int
+	}{
+		"server error": {status: http.StatusServiceUnavailable, attempts: 1},
+		"rate limited": {status: http.StatusTooManyRequests, attempts: 3},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				if attempts < 3 {
+					w.WriteHeader(testCase.status)
+					return
+				}
+				_, _ = w.Write([]byte("ok"))
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(withNonIdempotent(context.Background()), http.MethodPost, server.URL, strings.NewReader("api_option=paste"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body := req.Body
+			resp, err := newTestRetryClient(server, 3).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if attempts != testCase.attempts {
+				t.Errorf("expected %d attempts, got %d", testCase.attempts, attempts)
+			}
+			if req.Body != body {
+				t.Error("expected the body of the original request to be kept")
+			}
+		})
+	}
+}
+
+func TestRetryTransportNonIdempotentNetworkError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(withNonIdempotent(context.Background()), http.MethodPost, server.URL, strings.NewReader("api_option=paste"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newTestRetryClient(server, 3).Do(req); err == nil {
+		t.Fatal("expected the lost response to fail the request")
+	}
+	if attempts != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestRetryTransportGivesUpAfterMaxRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	resp, err := newTestRetryClient(server, 2).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || attempts != 3 {
+		t.Errorf("expected status 429 after 3 attempts, got status %d after %d attempts", resp.StatusCode, attempts)
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	resp, err := newTestRetryClient(server, 3).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if attempts != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestRetryTransportHonorsRetryAfter(t *testing.T) {
+	testCases := map[string]func() string{
+		"seconds": func() string {
+			return "1"
+		},
+		"http date": func() string {
+			return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat)
+		},
+	}
+
+	for name, retryAfter := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var first time.Time
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				if attempts == 1 {
+					first = time.Now()
+					w.Header().Set("Retry-After", retryAfter())
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+			}))
+			defer server.Close()
+
+			resp, err := newTestRetryClient(server, 1).Get(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK || attempts != 2 {
+				t.Errorf("expected success after 2 attempts, got status %d after %d attempts", resp.StatusCode, attempts)
+			}
+			if waited := time.Since(first); waited < 900*time.Millisecond {
+				t.Errorf("expected to wait for the Retry-After delay, waited %s", waited)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		"absent":    {},
+		"seconds":   {value: "120", expected: 2 * time.Minute, ok: true},
+		"http date": {value: "Mon, 01 Jan 2024 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		"past date": {value: "Mon, 01 Jan 2024 11:00:00 GMT", expected: 0, ok: true},
+		"invalid":   {value: "soon"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if testCase.value != "" {
+				resp.Header.Set("Retry-After", testCase.value)
+			}
+			delay, ok := parseRetryAfter(resp, now)
+			if delay != testCase.expected || ok != testCase.ok {
+				t.Errorf("expected %s (%t), got %s (%t)", testCase.expected, testCase.ok, delay, ok)
+			}
+		})
+	}
+}
+
+func TestUserAgentTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if r.UserAgent() != "terraform-provider-pastebin/test" {
+			t.Errorf("unexpected user agent %q", r.UserAgent())
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newUserAgentTransport(server.Client().Transport, "terraform-provider-pastebin/test"),
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestGzipTransport(t *testing.T) {
+	content := strings.Repeat("Hello from Terraform. ", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			_, _ = w.Write([]byte(content))
+			return
+		}
+		var compressed bytes.Buffer
+		writer := gzip.NewWriter(&compressed)
+		_, _ = writer.Write([]byte(content))
+		_ = writer.Close()
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Length", strconv.Itoa(compressed.Len()))
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	base := server.Client().Transport.(*http.Transport).Clone()
+	base.DisableCompression = true
+	resp, err := (&http.Client{Transport: newGzipTransport(base)}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != content {
+		t.Errorf("expected the decompressed content, got %d bytes", len(body))
+	}
+	if !resp.Uncompressed || resp.ContentLength != -1 || resp.Header.Get("Content-Encoding") != "" || resp.Header.Get("Content-Length") != "" {
+		t.Errorf("expected the response to be marked as uncompressed, got %v", resp.Header)
+	}
+	if resp.Header.Get("Content-Type") != "text/plain; charset=utf-8" {
+		t.Errorf("expected the content type to be kept, got %q", resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestDisableHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	for _, forceHTTP1 := range []bool{false, true} {
+		transport := server.Client().Transport.(*http.Transport).Clone()
+		if forceHTTP1 {
+			disableHTTP2(transport)
+		}
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		expected := "HTTP/2.0"
+		if forceHTTP1 {
+			expected = "HTTP/1.1"
+		}
+		if resp.Proto != expected {
+			t.Errorf("expected protocol %s when forcing HTTP/1.1 is %t, got %s", expected, forceHTTP1, resp.Proto)
+		}
+	}
+}
+
+func TestLoggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader("api_option=paste&api_dev_key=secret-dev&api_user_key=secret-user"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: newLoggingTransport(server.Client().Transport)}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	logs := output.String()
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+	if entries[1]["status"] != float64(http.StatusTeapot) || entries[1]["method"] != http.MethodPost {
+		t.Errorf("unexpected response log entry %v", entries[1])
+	}
+	if strings.Contains(logs, "secret") {
+		t.Errorf("expected the api keys to be redacted, got %s", logs)
+	}
+}
+
+func TestRedactFormBody(t *testing.T) {
+	body := redactFormBody("api_dev_key=dev&api_option=list&api_user_key=user", redactedFormKeys)
+	if body != "api_dev_key=%2A%2A%2A&api_option=list&api_user_key=%2A%2A%2A" {
+		t.Errorf("unexpected redacted body %q", body)
+	}
+}
+
+func TestLoggingTransportSensitiveContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := withSensitiveContent(tflogtest.RootLogger(context.Background(), &output))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader("api_option=paste&api_paste_code=secret-content"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: newLoggingTransport(server.Client().Transport)}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if logs := output.String(); strings.Contains(logs, "secret") || !strings.Contains(logs, "api_option=paste") {
+		t.Errorf("expected only the paste content to be redacted, got %s", logs)
+	}
+}
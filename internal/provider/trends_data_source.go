@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &trendsDataSource{}
+	_ datasource.DataSourceWithConfigure = &trendsDataSource{}
+)
+
+// NewTrendsDataSource is a helper function to simplify the provider implementation.
+func NewTrendsDataSource() datasource.DataSource {
+	return &trendsDataSource{}
+}
+
+// trendsDataSource is the data source implementation.
+type trendsDataSource struct {
+	client *pastebinClient
+}
+
+// trendsDataSourceModel maps the data source schema data.
+type trendsDataSourceModel struct {
+	Pastes []trendingPasteModel `tfsdk:"pastes"`
+}
+
+// trendingPasteModel maps the trending paste schema data.
+type trendingPasteModel struct {
+	Key   types.String `tfsdk:"key"`
+	Title types.String `tfsdk:"title"`
+	Date  types.String `tfsdk:"date"`
+	Hits  types.Int64  `tfsdk:"hits"`
+}
+
+// Metadata returns the data source type name.
+func (d *trendsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trends"
+}
+
+// Schema defines the schema for the data source.
+func (d *trendsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"pastes": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed: true,
+						},
+						"title": schema.StringAttribute{
+							Computed: true,
+						},
+						"date": schema.StringAttribute{
+							Computed: true,
+						},
+						"hits": schema.Int64Attribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *trendsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *trendsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	pastes, err := d.client.ListTrendingPastes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Trending Pastebin Pastes",
+			"Could not list the trending pastes: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	state := trendsDataSourceModel{
+		Pastes: []trendingPasteModel{},
+	}
+	for _, paste := range pastes {
+		state.Pastes = append(state.Pastes, trendingPasteModel{
+			Key:   types.StringValue(paste.Key),
+			Title: types.StringValue(paste.Title),
+			Date:  unixTimestamp(paste.Date),
+			Hits:  types.Int64Value(paste.Hits),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
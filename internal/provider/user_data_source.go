@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &userDataSource{}
+	_ datasource.DataSourceWithConfigure        = &userDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &userDataSource{}
+)
+
+// NewUserDataSource is a helper function to simplify the provider implementation.
+func NewUserDataSource() datasource.DataSource {
+	return &userDataSource{}
+}
+
+// userDataSource is the data source implementation.
+type userDataSource struct {
+	client *pastebinClient
+}
+
+// userDataSourceModel maps the data source schema data.
+type userDataSourceModel struct {
+	Username          types.String `tfsdk:"username"`
+	DefaultFormat     types.String `tfsdk:"default_format"`
+	DefaultExpiration types.String `tfsdk:"default_expiration"`
+	DefaultVisibility types.String `tfsdk:"default_visibility"`
+	AvatarUrl         types.String `tfsdk:"avatar_url"`
+	Website           types.String `tfsdk:"website"`
+	Email             types.String `tfsdk:"email"`
+	Location          types.String `tfsdk:"location"`
+	AccountType       types.String `tfsdk:"account_type"`
+}
+
+// userAccountTypes maps the user_account_type values to their names.
+var userAccountTypes = map[string]string{
+	"0": "normal",
+	"1": "pro",
+}
+
+// Metadata returns the data source type name.
+func (d *userDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// Schema defines the schema for the data source.
+func (d *userDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Computed: true,
+			},
+			"default_format": schema.StringAttribute{
+				Computed: true,
+			},
+			"default_expiration": schema.StringAttribute{
+				Computed: true,
+			},
+			"default_visibility": schema.StringAttribute{
+				Computed: true,
+			},
+			"avatar_url": schema.StringAttribute{
+				Computed: true,
+			},
+			"website": schema.StringAttribute{
+				Computed: true,
+			},
+			"email": schema.StringAttribute{
+				Computed: true,
+			},
+			"location": schema.StringAttribute{
+				Computed: true,
+			},
+			"account_type": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ConfigValidators returns the validators for the data source configuration.
+func (d *userDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		requiresUserKey(d.client, "Reading the account details of a user"),
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *userDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Reading the account details requires both api keys
+	if d.client.client.DevKey == "" {
+		resp.Diagnostics.AddError(
+			"Missing PasteBin API Dev Key",
+			"Reading the account details of a user requires a dev key. "+
+				"Set the dev_key value in the provider configuration or use the PASTEBIN_DEV_KEY environment variable. "+
+				"A dev key can be found at https://pastebin.com/doc_api when logged in.",
+		)
+	}
+	if d.client.client.UserKey == "" {
+		resp.Diagnostics.Append(missingUserKeyError("Reading the account details of a user"))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	details, err := d.client.GetUserDetails(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Pastebin User",
+			"Could not read the account details of the user: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	state := userDataSourceModel{
+		Username:          types.StringValue(details.Name),
+		DefaultFormat:     types.StringValue(details.FormatShort),
+		DefaultExpiration: types.StringValue(details.Expiration),
+		DefaultVisibility: types.StringValue(pasteVisibility(details.Private)),
+		AvatarUrl:         types.StringValue(details.AvatarUrl),
+		Website:           types.StringValue(details.Website),
+		Email:             types.StringValue(details.Email),
+		Location:          types.StringValue(details.Location),
+		AccountType:       types.StringValue(userAccountTypes[details.AccountType]),
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
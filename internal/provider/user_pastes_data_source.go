@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &userPastesDataSource{}
+	_ datasource.DataSourceWithConfigure        = &userPastesDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &userPastesDataSource{}
+)
+
+// NewUserPastesDataSource is a helper function to simplify the provider implementation.
+func NewUserPastesDataSource() datasource.DataSource {
+	return &userPastesDataSource{}
+}
+
+// userPastesDataSource is the data source implementation.
+type userPastesDataSource struct {
+	client *pastebinClient
+}
+
+// userPastesDataSourceModel maps the data source schema data.
+type userPastesDataSourceModel struct {
+	Limit  types.Int64       `tfsdk:"limit"`
+	Format types.String      `tfsdk:"format"`
+	Pastes []userPastesModel `tfsdk:"pastes"`
+	JSON   types.String      `tfsdk:"json"`
+}
+
+// userPastesModel maps the paste schema data.
+type userPastesModel struct {
+	Key        types.String `tfsdk:"key"`
+	Title      types.String `tfsdk:"title"`
+	Date       types.String `tfsdk:"date"`
+	Size       types.Int64  `tfsdk:"size"`
+	Visibility types.String `tfsdk:"visibility"`
+	Format     types.String `tfsdk:"format"`
+	Expiration types.String `tfsdk:"expiration"`
+}
+
+// userPastesJSON is the JSON encoding of a paste in the json attribute, using
+// the same names as the attributes of the paste.
+type userPastesJSON struct {
+	Key        string  `json:"key"`
+	Title      string  `json:"title"`
+	Date       *string `json:"date"`
+	Size       int64   `json:"size"`
+	Visibility string  `json:"visibility"`
+	Format     string  `json:"format"`
+	Expiration *string `json:"expiration"`
+}
+
+// json returns the JSON encoding of the paste.
+func (m userPastesModel) json() userPastesJSON {
+	return userPastesJSON{
+		Key:        m.Key.ValueString(),
+		Title:      m.Title.ValueString(),
+		Date:       m.Date.ValueStringPointer(),
+		Size:       m.Size.ValueInt64(),
+		Visibility: m.Visibility.ValueString(),
+		Format:     m.Format.ValueString(),
+		Expiration: m.Expiration.ValueStringPointer(),
+	}
+}
+
+// Metadata returns the data source type name.
+func (d *userPastesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pastes"
+}
+
+// Schema defines the schema for the data source.
+func (d *userPastesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"limit": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64Between(1, maxListResults),
+				},
+			},
+			// Only lists the pastes with this format. The limit applies to
+			// the pastes with the format.
+			"format": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					pasteFormat(),
+				},
+			},
+			"pastes": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed: true,
+						},
+						"title": schema.StringAttribute{
+							Computed: true,
+						},
+						"date": schema.StringAttribute{
+							Computed: true,
+						},
+						"size": schema.Int64Attribute{
+							Computed: true,
+						},
+						"visibility": schema.StringAttribute{
+							Computed: true,
+						},
+						"format": schema.StringAttribute{
+							Computed: true,
+						},
+						"expiration": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			// The pastes as JSON, for transformations that are not expressible
+			// with the pastes attribute. The list only contains metadata.
+			"json": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ConfigValidators returns the validators for the data source configuration.
+func (d *userPastesDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		requiresUserKey(d.client, "Listing the pastes of a user"),
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *userPastesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*pastebinProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pastebinProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *userPastesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state userPastesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Listing pastes requires an authenticated user
+	if d.client.client.UserKey == "" {
+		resp.Diagnostics.Append(missingUserKeyError("Listing the pastes of a user"))
+		return
+	}
+
+	limit := int64(50)
+	if !state.Limit.IsNull() {
+		limit = state.Limit.ValueInt64()
+	}
+
+	// The list api cannot filter by format, so all pastes are listed and
+	// filtered before the limit is applied
+	listLimit := limit
+	if !state.Format.IsNull() {
+		listLimit = maxListResults
+	}
+	pastes, err := d.client.ListPastes(ctx, int(listLimit))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Pastebin Pastes",
+			"Could not list the pastes of the user: "+err.Error(),
+		)
+		return
+	}
+
+	// The list api has no paging, so pastes beyond its maximum number of
+	// results cannot be listed
+	if len(pastes) >= maxListResults {
+		resp.Diagnostics.AddWarning(
+			"Incomplete Pastebin Paste List",
+			fmt.Sprintf("The user has at least %d pastes, which is the maximum number of pastes the PasteBin API lists. "+
+				"Pastes beyond this limit are not listed.", maxListResults),
+		)
+	}
+
+	// Map response body to model
+	state.Pastes = []userPastesModel{}
+	encoded := []userPastesJSON{}
+	for _, paste := range pastes {
+		if !state.Format.IsNull() && paste.FormatShort != state.Format.ValueString() {
+			continue
+		}
+		if int64(len(state.Pastes)) >= limit {
+			break
+		}
+		model := userPastesModel{
+			Key:        types.StringValue(paste.Key),
+			Title:      types.StringValue(paste.Title),
+			Date:       unixTimestamp(paste.Date),
+			Size:       types.Int64Value(paste.Size),
+			Visibility: types.StringValue(pasteVisibility(paste.Private)),
+			Format:     types.StringValue(paste.FormatShort),
+			Expiration: unixTimestamp(paste.ExpireDate),
+		}
+		state.Pastes = append(state.Pastes, model)
+		encoded = append(encoded, model.json())
+	}
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Encode Pastebin Pastes",
+			"Could not encode the pastes of the user as JSON: "+err.Error(),
+		)
+		return
+	}
+	state.JSON = types.StringValue(string(data))
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// unixTimestamp formats a unix timestamp from the Pastebin api as RFC3339, or
+// returns null if the timestamp is not set.
+func unixTimestamp(seconds int64) types.String {
+	if seconds == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(time.Unix(seconds, 0).UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-pastebin/internal/pastebintest"
+)
+
+func TestUserPastesDataSourceReadJSON(t *testing.T) {
+	server := pastebintest.New()
+	defer server.Close()
+	key := server.AddPaste(pastebintest.Paste{Content: "Secret content.", Title: "Example", Format: "go", Private: "2", Owner: server.UserKey})
+
+	attributes, diagnostics := testReadDataSource(t, testFakeProviderConfig(server), "pastebin_pastes", map[string]tftypes.Value{})
+	if testHasError(diagnostics) {
+		t.Fatalf("unexpected error %v", diagnostics)
+	}
+	var encoded string
+	if err := attributes["json"].As(&encoded); err != nil {
+		t.Fatalf("unexpected json value %v: %s", attributes["json"], err)
+	}
+	if strings.Contains(encoded, "Secret content.") {
+		t.Errorf("expected json not to contain the paste content, got %s", encoded)
+	}
+
+	var pastes []map[string]interface{}
+	if err := json.Unmarshal([]byte(encoded), &pastes); err != nil {
+		t.Fatalf("unexpected invalid json %s: %s", encoded, err)
+	}
+	if len(pastes) != 1 {
+		t.Fatalf("expected 1 paste, got %s", encoded)
+	}
+	expected := map[string]interface{}{
+		"key":        key,
+		"title":      "Example",
+		"visibility": "private",
+		"format":     "go",
+	}
+	for name, value := range expected {
+		if pastes[0][name] != value {
+			t.Errorf("expected %s %v, got %v", name, value, pastes[0][name])
+		}
+	}
+}
+
+func TestUserPastesDataSourceReadFormat(t *testing.T) {
+	testCases := map[string]struct {
+		config   map[string]tftypes.Value
+		expected int
+	}{
+		"all formats": {
+			config:   map[string]tftypes.Value{},
+			expected: 4,
+		},
+		"matching format": {
+			config: map[string]tftypes.Value{
+				"format": tftypes.NewValue(tftypes.String, "json"),
+			},
+			expected: 3,
+		},
+		"limit applies to matching format": {
+			config: map[string]tftypes.Value{
+				"format": tftypes.NewValue(tftypes.String, "json"),
+				"limit":  tftypes.NewValue(tftypes.Number, 2),
+			},
+			expected: 2,
+		},
+		"no matching format": {
+			config: map[string]tftypes.Value{
+				"format": tftypes.NewValue(tftypes.String, "python"),
+			},
+			expected: 0,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := pastebintest.New()
+			defer server.Close()
+			server.AddPaste(pastebintest.Paste{Content: "Hello from Go.", Format: "go", Private: "2", Owner: server.UserKey})
+			for i := 0; i < 3; i++ {
+				server.AddPaste(pastebintest.Paste{Content: "{}", Format: "json", Private: "2", Owner: server.UserKey})
+			}
+
+			attributes, diagnostics := testReadDataSource(t, testFakeProviderConfig(server), "pastebin_pastes", testCase.config)
+			if testHasError(diagnostics) {
+				t.Fatalf("unexpected error %v", diagnostics)
+			}
+			var pastes []tftypes.Value
+			if err := attributes["pastes"].As(&pastes); err != nil {
+				t.Fatal(err)
+			}
+			if len(pastes) != testCase.expected {
+				t.Fatalf("expected %d pastes, got %d", testCase.expected, len(pastes))
+			}
+			format, hasFormat := testCase.config["format"]
+			for _, paste := range pastes {
+				var attrs map[string]tftypes.Value
+				if err := paste.As(&attrs); err != nil {
+					t.Fatal(err)
+				}
+				if hasFormat && !attrs["format"].Equal(format) {
+					t.Errorf("expected format %v, got %v", format, attrs["format"])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,675 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ validator.String           = stringOneOfValidator{}
+	_ validator.String           = pasteFormatValidator{}
+	_ validator.Int64            = int64BetweenValidator{}
+	_ validator.String           = stringNotBlankValidator{}
+	_ validator.String           = stringTruncatedValidator{}
+	_ validator.String           = stringDurationValidator{}
+	_ resource.ConfigValidator   = exactlyOneOfValidator{}
+	_ provider.ConfigValidator   = requiresWithValidator{}
+	_ resource.ConfigValidator   = userKeyRequiredValidator{}
+	_ datasource.ConfigValidator = userKeyRequiredValidator{}
+)
+
+// stringOneOfValidator validates that a string is one of the allowed values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+// stringOneOf returns a validator which ensures that a configured string is
+// one of the given values. Null and unknown values are not validated.
+func stringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{
+		values: values,
+	}
+}
+
+// Description describes the validation in plain text formatting.
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", quoteValues(v.values))
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	for _, allowed := range v.values {
+		if value == allowed {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
+
+// pasteExpireValidator validates that a string is a paste expiration.
+type pasteExpireValidator struct{}
+
+// pasteExpire returns a validator which ensures that a string is a paste
+// expiration, which is either an api_paste_expire_date value such as 1W or a
+// human-friendly alias such as "1 week".
+func pasteExpire() validator.String {
+	return pasteExpireValidator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v pasteExpireValidator) Description(_ context.Context) string {
+	aliases := make([]string, 0, len(pasteExpireAliases))
+	for _, code := range pasteExpireDates {
+		for alias, aliasCode := range pasteExpireAliases {
+			if aliasCode == code {
+				aliases = append(aliases, alias)
+			}
+		}
+	}
+	return fmt.Sprintf("value must be one of: %s, or one of the aliases: %s", quoteValues(pasteExpireDates), quoteValues(aliases))
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v pasteExpireValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v pasteExpireValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, ok := normalizeExpire(req.ConfigValue.ValueString()); ok {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+	)
+}
+
+// quoteValues returns the values as a comma separated list of quoted strings.
+func quoteValues(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// stringNotBlankValidator validates that a string is not empty or whitespace only.
+type stringNotBlankValidator struct{}
+
+// stringNotBlank returns a validator which ensures that a configured string
+// contains at least one non-whitespace character. Null and unknown values are
+// not validated.
+func stringNotBlank() validator.String {
+	return stringNotBlankValidator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v stringNotBlankValidator) Description(_ context.Context) string {
+	return "value must not be empty or contain only whitespace"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v stringNotBlankValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringNotBlankValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if strings.TrimSpace(req.ConfigValue.ValueString()) == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, as Pastebin rejects such values.", req.Path, v.Description(ctx)),
+		)
+	}
+}
+
+// stringBase64Validator validates that a string is base64 encoded content.
+type stringBase64Validator struct{}
+
+// stringBase64 returns a validator which ensures that a configured string is
+// standard base64 encoded, padded content that is not empty. Null and unknown
+// values are not validated.
+func stringBase64() validator.String {
+	return stringBase64Validator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v stringBase64Validator) Description(_ context.Context) string {
+	return "value must be base64 encoded content that is not empty"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v stringBase64Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringBase64Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := decodeContentBase64(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, but %s.", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// stringTruncatedValidator warns when a string is longer than Pastebin stores.
+type stringTruncatedValidator struct {
+	maxLength int
+}
+
+// stringTruncated returns a validator which warns if a configured string is
+// longer than maxLength characters, as Pastebin silently truncates such
+// values. Null and unknown values are not validated.
+func stringTruncated(maxLength int) validator.String {
+	return stringTruncatedValidator{
+		maxLength: maxLength,
+	}
+}
+
+// Description describes the validation in plain text formatting.
+func (v stringTruncatedValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value should be at most %d characters", v.maxLength)
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v stringTruncatedValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringTruncatedValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	length := utf8.RuneCountInString(req.ConfigValue.ValueString())
+	if length > v.maxLength {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Attribute Value Will Be Truncated",
+			fmt.Sprintf("Attribute %s is %d characters long, but Pastebin truncates it to %d characters. "+
+				"The stored value will not match the configured value.", req.Path, length, v.maxLength),
+		)
+	}
+}
+
+// stringDurationValidator validates that a string is a positive duration.
+type stringDurationValidator struct{}
+
+// stringDuration returns a validator which ensures that a configured string
+// is a positive duration such as "30s" or "5m". Null and unknown values are
+// not validated.
+func stringDuration() validator.String {
+	return stringDurationValidator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v stringDurationValidator) Description(_ context.Context) string {
+	return `value must be a positive duration such as "30s" or "5m"`
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v stringDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringDurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// pasteKeyValidator validates that a string is a paste key or paste url.
+type pasteKeyValidator struct{}
+
+// pasteKeyOrUrl returns a validator which ensures that a configured string is a
+// paste key of 8 alphanumeric characters, or a paste url that ends with such
+// a key. Null and unknown values are not validated.
+func pasteKeyOrUrl() validator.String {
+	return pasteKeyValidator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v pasteKeyValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a paste key of %d letters and digits, or a paste url", pasteKeyLength)
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v pasteKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v pasteKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parsePasteKey(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, such as abcd1234 or https://pastebin.com/abcd1234, but the %s.", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// pasteFormatValidator validates that a string is a supported paste format.
+type pasteFormatValidator struct{}
+
+// pasteFormat returns a validator which ensures that a configured string is
+// one of the supported paste formats. Null and unknown values are not validated.
+func pasteFormat() validator.String {
+	return pasteFormatValidator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v pasteFormatValidator) Description(_ context.Context) string {
+	return "value must be a supported Pastebin syntax highlighting format, see https://pastebin.com/doc_api#5"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v pasteFormatValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v pasteFormatValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if isPasteFormat(value) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Paste Format",
+		fmt.Sprintf("Attribute %s %s, got: %q. Did you mean one of: %s?", req.Path, v.Description(ctx), value, quoteValues(closestPasteFormats(value, 3))),
+	)
+}
+
+// isPasteFormat returns whether the value is a supported paste format.
+func isPasteFormat(value string) bool {
+	for _, format := range pasteFormats {
+		if value == format {
+			return true
+		}
+	}
+	return false
+}
+
+// closestPasteFormats returns the n supported paste formats that are closest
+// to the value by edit distance.
+func closestPasteFormats(value string, n int) []string {
+	value = strings.ToLower(value)
+	formats := make([]string, len(pasteFormats))
+	copy(formats, pasteFormats)
+	sort.SliceStable(formats, func(i, j int) bool {
+		return editDistance(value, formats[i]) < editDistance(value, formats[j])
+	})
+	return formats[:n]
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// int64BetweenValidator validates that an integer is within a range.
+type int64BetweenValidator struct {
+	min, max int64
+}
+
+// int64Between returns a validator which ensures that a configured integer is
+// between min and max inclusive. Null and unknown values are not validated.
+func int64Between(min, max int64) validator.Int64 {
+	return int64BetweenValidator{
+		min: min,
+		max: max,
+	}
+}
+
+// Description describes the validation in plain text formatting.
+func (v int64BetweenValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be between %d and %d", v.min, v.max)
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v int64BetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateInt64 performs the validation.
+func (v int64BetweenValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueInt64()
+	if value < v.min || value > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// exactlyOneOfValidator validates that exactly one of the attributes is configured.
+type exactlyOneOfValidator struct {
+	paths []path.Path
+}
+
+// exactlyOneOf returns a validator which ensures that exactly one of the
+// attributes at the given paths is configured. The validation is skipped if
+// any of the attributes is unknown.
+func exactlyOneOf(paths ...path.Path) resource.ConfigValidator {
+	return exactlyOneOfValidator{
+		paths: paths,
+	}
+}
+
+// Description describes the validation in plain text formatting.
+func (v exactlyOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("exactly one of these attributes must be configured: %s", v.pathNames())
+}
+
+// pathNames returns the paths as a comma separated list between brackets.
+func (v exactlyOneOfValidator) pathNames() string {
+	names := make([]string, len(v.paths))
+	for i, p := range v.paths {
+		names[i] = p.String()
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v exactlyOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation.
+func (v exactlyOneOfValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	configured := 0
+	for _, p := range v.paths {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if value.IsUnknown() {
+			return
+		}
+		if !value.IsNull() {
+			configured++
+		}
+	}
+
+	if configured != 1 {
+		resp.Diagnostics.AddAttributeError(
+			v.paths[0],
+			"Invalid Attribute Combination",
+			fmt.Sprintf("Exactly one of these attributes must be configured: %s, got %d configured.", v.pathNames(), configured),
+		)
+	}
+}
+
+// atLeastOneOfValidator validates that at least one of the attributes is configured.
+type atLeastOneOfValidator struct {
+	paths []path.Path
+}
+
+// atLeastOneOf returns a validator which ensures that at least one of the
+// attributes at the given paths is configured. The validation is skipped if
+// any of the attributes is unknown.
+func atLeastOneOf(paths ...path.Path) resource.ConfigValidator {
+	return atLeastOneOfValidator{
+		paths: paths,
+	}
+}
+
+// Description describes the validation in plain text formatting.
+func (v atLeastOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("at least one of these attributes must be configured: %s", exactlyOneOfValidator(v).pathNames())
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v atLeastOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation.
+func (v atLeastOneOfValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	for _, p := range v.paths {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &value)...)
+		if resp.Diagnostics.HasError() || value.IsUnknown() || !value.IsNull() {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		v.paths[0],
+		"Missing Attribute Configuration",
+		fmt.Sprintf("At least one of these attributes must be configured: %s.", exactlyOneOfValidator(v).pathNames()),
+	)
+}
+
+// requiresWithValidator validates that an attribute is only configured
+// together with one of the required attributes or their environment variable.
+type requiresWithValidator struct {
+	attribute   path.Path
+	requiredEnv string
+	required    []path.Path
+}
+
+// requiresWith returns a validator which ensures that if the attribute at the
+// given path is configured, one of the required attributes is configured with
+// a non empty value as well, or the value is set in the environment variable
+// instead. The validation is skipped if any of the attributes is unknown.
+func requiresWith(attribute path.Path, requiredEnv string, required ...path.Path) provider.ConfigValidator {
+	return requiresWithValidator{
+		attribute:   attribute,
+		requiredEnv: requiredEnv,
+		required:    required,
+	}
+}
+
+// Description describes the validation in plain text formatting.
+func (v requiresWithValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("%s requires one of %s or the %s environment variable to be set", v.attribute, v.requiredNames(), v.requiredEnv)
+}
+
+// requiredNames returns the required paths as a comma separated list between brackets.
+func (v requiresWithValidator) requiredNames() string {
+	names := make([]string, len(v.required))
+	for i, p := range v.required {
+		names[i] = p.String()
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v requiresWithValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateProvider performs the validation.
+func (v requiresWithValidator) ValidateProvider(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var value attr.Value
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.attribute, &value)...)
+	if resp.Diagnostics.HasError() || value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	if os.Getenv(v.requiredEnv) != "" {
+		return
+	}
+	for _, p := range v.required {
+		var required types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &required)...)
+		if resp.Diagnostics.HasError() || required.IsUnknown() || required.ValueString() != "" {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		v.attribute,
+		"Invalid Attribute Combination",
+		fmt.Sprintf("Attribute %s requires one of %s to be configured, or the %s environment variable to be set.", v.attribute, v.requiredNames(), v.requiredEnv),
+	)
+}
+
+// userKeyRequiredValidator validates that the provider is configured with a
+// user key, if the attribute has the given value or for any configuration if
+// no attribute is set.
+type userKeyRequiredValidator struct {
+	client    *pastebinClient
+	operation string
+	attribute path.Path
+	value     string
+}
+
+// requiresUserKey returns a validator which ensures that the provider of the
+// data source is configured with a user key. The validation is skipped until
+// the provider is configured, so the data source has to check it on read too.
+func requiresUserKey(client *pastebinClient, operation string) datasource.ConfigValidator {
+	return userKeyRequiredValidator{
+		client:    client,
+		operation: operation,
+	}
+}
+
+// requiresUserKeyIf returns a validator which ensures that the provider of the
+// resource is configured with a user key if the attribute at the given path
+// has the value. The validation is skipped until the provider is configured,
+// so the resource has to check it during plan too.
+func requiresUserKeyIf(client *pastebinClient, operation string, attribute path.Path, value string) resource.ConfigValidator {
+	return userKeyRequiredValidator{
+		client:    client,
+		operation: operation,
+		attribute: attribute,
+		value:     value,
+	}
+}
+
+// Description describes the validation in plain text formatting.
+func (v userKeyRequiredValidator) Description(_ context.Context) string {
+	if v.attribute.Equal(path.Empty()) {
+		return "the provider must be configured with a user key"
+	}
+	return fmt.Sprintf("the provider must be configured with a user key if %s is %q", v.attribute, v.value)
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v userKeyRequiredValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation.
+func (v userKeyRequiredValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config)...)
+}
+
+// ValidateDataSource performs the validation.
+func (v userKeyRequiredValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config)...)
+}
+
+// validate performs the validation of the configuration.
+func (v userKeyRequiredValidator) validate(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if v.client == nil || v.client.client.UserKey != "" {
+		return diags
+	}
+	if v.attribute.Equal(path.Empty()) {
+		diags.Append(missingUserKeyError(v.operation))
+		return diags
+	}
+
+	var value types.String
+	diags.Append(config.GetAttribute(ctx, v.attribute, &value)...)
+	if diags.HasError() || value.ValueString() != v.value {
+		return diags
+	}
+	diags.Append(diag.WithPath(v.attribute, missingUserKeyError(v.operation)))
+	return diags
+}
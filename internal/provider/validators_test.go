@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validateString runs the validator against the value and returns the response.
+func validateString(v validator.String, value types.String) *validator.StringResponse {
+	resp := &validator.StringResponse{}
+	v.ValidateString(context.Background(), validator.StringRequest{
+		Path:        path.Root("test"),
+		ConfigValue: value,
+	}, resp)
+	return resp
+}
+
+func TestStringOneOfValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"allowed": {value: types.StringValue("1D")},
+		"null":    {value: types.StringNull()},
+		"unknown": {value: types.StringUnknown()},
+		"invalid": {value: types.StringValue("2D"), expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := validateString(stringOneOf(pasteExpireDates...), testCase.value)
+			if resp.Diagnostics.HasError() != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPasteFormatValidator(t *testing.T) {
+	for _, format := range []string{"bash", "go", "json", "python", "yaml", "text"} {
+		if resp := validateString(pasteFormat(), types.StringValue(format)); resp.Diagnostics.HasError() {
+			t.Errorf("expected format %q to be valid, got %v", format, resp.Diagnostics)
+		}
+	}
+
+	resp := validateString(pasteFormat(), types.StringValue("pyhton"))
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for an invalid format")
+	}
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, `"python"`) {
+		t.Errorf("expected the closest matches to contain python, got %s", detail)
+	}
+}
+
+func TestPasteExpireValidator(t *testing.T) {
+	for _, expire := range []string{"N", "1W", "never", "1 week", "6 Months"} {
+		if resp := validateString(pasteExpire(), types.StringValue(expire)); resp.Diagnostics.HasError() {
+			t.Errorf("expected expire %q to be valid, got %v", expire, resp.Diagnostics)
+		}
+	}
+
+	resp := validateString(pasteExpire(), types.StringValue("2 days"))
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for an invalid expire")
+	}
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, `"1W"`) || !strings.Contains(detail, `"1 week"`) {
+		t.Errorf("expected the accepted forms to contain 1W and 1 week, got %s", detail)
+	}
+}
+
+func TestPasteKeyOrUrlValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"key":                {value: types.StringValue("abcd1234")},
+		"url":                {value: types.StringValue("https://pastebin.com/abcd1234")},
+		"raw url":            {value: types.StringValue("https://pastebin.com/raw/abcd1234/")},
+		"null":               {value: types.StringNull()},
+		"unknown":            {value: types.StringUnknown()},
+		"too short":          {value: types.StringValue("abcd"), expectErr: true},
+		"invalid characters": {value: types.StringValue("abcd_123"), expectErr: true},
+		"url without key":    {value: types.StringValue("https://pastebin.com/"), expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := validateString(pasteKeyOrUrl(), testCase.value)
+			if resp.Diagnostics.HasError() != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestParsePasteKey(t *testing.T) {
+	for _, value := range []string{"abcd1234", "https://pastebin.com/abcd1234", "https://pastebin.com/raw/abcd1234", "pastebin.com/abcd1234", "/raw/abcd1234"} {
+		if pasteKey, err := parsePasteKey(value); pasteKey != "abcd1234" || err != nil {
+			t.Errorf("expected paste key abcd1234 for %q, got %q (%v)", value, pasteKey, err)
+		}
+	}
+}
+
+func TestPasteUrlHost(t *testing.T) {
+	testCases := map[string]string{
+		"abcd1234":                          "",
+		"/raw/abcd1234":                     "",
+		"https://pastebin.com/abcd1234":     "pastebin.com",
+		"https://pastebin.com/raw/abcd1234": "pastebin.com",
+		"pastebin.com/abcd1234":             "pastebin.com",
+		"http://localhost:8080/abcd1234":    "localhost",
+	}
+
+	for value, expected := range testCases {
+		if host := pasteUrlHost(value); host != expected {
+			t.Errorf("expected host %q for %q, got %q", expected, value, host)
+		}
+	}
+	if !sameHost("www.Pastebin.com", "pastebin.com") || sameHost("paste.example.com", "pastebin.com") {
+		t.Error("expected hosts to be compared ignoring case and a www subdomain")
+	}
+}
+
+func TestClosestPasteFormats(t *testing.T) {
+	closest := closestPasteFormats("JavaScrpt", 3)
+	if len(closest) != 3 || closest[0] != "javascript" {
+		t.Errorf("expected javascript to be the closest format, got %v", closest)
+	}
+}
+
+func TestStringTruncatedValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectWarns bool
+	}{
+		"short":     {value: types.StringValue("greeting")},
+		"max":       {value: types.StringValue(strings.Repeat("é", 10))},
+		"null":      {value: types.StringNull()},
+		"truncated": {value: types.StringValue(strings.Repeat("a", 11)), expectWarns: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := validateString(stringTruncated(10), testCase.value)
+			if resp.Diagnostics.HasError() {
+				t.Errorf("expected no error, got %v", resp.Diagnostics)
+			}
+			if (resp.Diagnostics.WarningsCount() > 0) != testCase.expectWarns {
+				t.Errorf("expected warning %t, got %v", testCase.expectWarns, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestInt64BetweenValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.Int64
+		expectErr bool
+	}{
+		"min":       {value: types.Int64Value(1)},
+		"max":       {value: types.Int64Value(1000)},
+		"null":      {value: types.Int64Null()},
+		"too small": {value: types.Int64Value(0), expectErr: true},
+		"too large": {value: types.Int64Value(1001), expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.Int64Response{}
+			int64Between(1, 1000).ValidateInt64(context.Background(), validator.Int64Request{
+				Path:        path.Root("test"),
+				ConfigValue: testCase.value,
+			}, resp)
+			if resp.Diagnostics.HasError() != testCase.expectErr {
+				t.Errorf("expected error %t, got %v", testCase.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}